@@ -15,8 +15,8 @@ import (
 	"os"
 
 	"github.com/atc0005/check-process/internal/config"
-	"github.com/atc0005/check-process/internal/process"
-	"github.com/atc0005/check-process/internal/reports"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/atc0005/check-process/pkg/procstate/reports"
 	"github.com/atc0005/go-nagios"
 	"github.com/rs/zerolog"
 )
@@ -67,17 +67,39 @@ func main() {
 
 	logger := cfg.Log.With().Logger()
 
-	logger.Debug().
-		Str("base_path", process.ProcRootDir).
-		Msg("Collecting process paths")
-	procDirs, err := process.GetProcDirs(process.ProcRootDir)
+	var opts []procstate.Option
+	if cfg.Archive != "" {
+		logger.Debug().
+			Str("archive", cfg.Archive).
+			Msg("Extracting capture archive")
+
+		procRoot, cleanup, err := procstate.ExtractArchiveFile(cfg.Archive)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to extract capture archive")
+
+			plugin.AddError(err)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to extract capture archive %s",
+				nagios.StateUNKNOWNLabel,
+				cfg.Archive,
+			)
+
+			return
+		}
+		defer cleanup()
+
+		opts = append(opts, procstate.WithProcRoot(procRoot))
+	}
+
+	collector, err := procstate.NewCollector(opts...)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to evaluate process directories")
+		logger.Error().Err(err).Msg("Failed to initialize process collector")
 
 		plugin.AddError(err)
 		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 		plugin.ServiceOutput = fmt.Sprintf(
-			"%s: Failed to evaluate process directories",
+			"%s: Failed to initialize process collector",
 			nagios.StateUNKNOWNLabel,
 		)
 
@@ -85,11 +107,18 @@ func main() {
 	}
 
 	logger.Debug().
-		Str("base_path", process.ProcRootDir).
-		Int("process_paths", len(procDirs)).
-		Msg("Successfully collected process paths")
+		Str("base_path", collector.ProcRoot()).
+		Msg("Collecting processes")
+
+	snapshot, err := collector.Snapshot()
+	if err == nil {
+		// Processes which could not be evaluated prevent an accurate
+		// assessment, so we treat the first one as a collection failure.
+		if procErrs := snapshot.ProcessErrors(); len(procErrs) > 0 {
+			err = procErrs[0]
+		}
+	}
 
-	processes, err := process.FromProcDirs(procDirs)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to obtain list of process values")
 
@@ -100,24 +129,164 @@ func main() {
 			nagios.StateUNKNOWNLabel,
 		)
 
+		var parseErr *procstate.ParseError
+		if errors.As(err, &parseErr) {
+			logger.Error().
+				Str("path", parseErr.Path).
+				Int("line", parseErr.Line).
+				Str("key", parseErr.Key).
+				Str("value", parseErr.Value).
+				Msg("Failed to parse proc file")
+
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to parse proc file %s",
+				nagios.StateUNKNOWNLabel,
+				parseErr.Path,
+			)
+		}
+
 		return
 	}
 
+	snapshot.ToolVersion = config.Version()
+	processes := snapshot.Processes
+
+	for _, snapshotErr := range snapshot.Errors {
+		// Remaining errors are for capture details provided as context
+		// only, so we note them without changing the service check state.
+		logger.Warn().Err(snapshotErr).Msg("Failed to gather snapshot details")
+	}
+
 	logger.Debug().
 		Int("processes", len(processes)).
+		Dur("duration", snapshot.Duration()).
 		Msg("Collected info on processes")
 
-	logger.Debug().
-		Str("my_name", os.Args[0]).
-		Int("my_process_id", os.Getpid()).
-		Msg("Excluding process of current tool")
-	processes = processes.ExcludeMyPID()
+	switch {
+	case cfg.Archive != "":
+		// The process of this tool (and its ancestry) is not present in a
+		// capture archive, so there is nothing to exclude.
+		logger.Debug().Msg("Evaluating capture archive; skipping exclusion of current tool")
+
+	case cfg.ExcludeAncestors || cfg.ExcludeAgentChildren:
+		logger.Debug().
+			Str("my_name", os.Args[0]).
+			Int("my_process_id", os.Getpid()).
+			Int("my_ancestors", processes.MyAncestors().Count()).
+			Bool("include_agent_children", cfg.ExcludeAgentChildren).
+			Msg("Excluding process and ancestry of current tool")
+		processes = processes.ExcludeMyAncestry(cfg.ExcludeAgentChildren)
+
+		logger.Debug().
+			Int("processes", len(processes)).
+			Msg("Excluded process and ancestry of current tool")
+
+	default:
+		logger.Debug().
+			Str("my_name", os.Args[0]).
+			Int("my_process_id", os.Getpid()).
+			Msg("Excluding process of current tool")
+		processes = processes.ExcludeMyPID()
+
+		logger.Debug().
+			Int("processes", len(processes)).
+			Msg("Excluded process of current tool")
+	}
+
+	var hungTaskSettings *procstate.HungTaskSettings
+	if cfg.HungTaskSource != "" {
+		logger.Debug().
+			Str("hung_task_source", cfg.HungTaskSource).
+			Msg("Collecting kernel hung task reports")
+
+		var hungTasks procstate.HungTasks
+		switch cfg.HungTaskSource {
+		case procstate.KmsgSource:
+			hungTasks, err = procstate.HungTasksFromKmsg()
+		default:
+			hungTasks, err = procstate.HungTasksFromFile(cfg.HungTaskSource)
+		}
+
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to collect kernel hung task reports")
+
+			plugin.AddError(err)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to collect kernel hung task reports",
+				nagios.StateUNKNOWNLabel,
+			)
+
+			return
+		}
+
+		processes = processes.AnnotateHungTasks(hungTasks)
+
+		logger.Debug().
+			Int("hung_tasks", len(hungTasks)).
+			Int("blocked_processes", processes.KernelBlocked().Count()).
+			Msg("Annotated processes reported as blocked by kernel")
+
+		settings, settingsErr := procstate.GetHungTaskSettings(snapshot.ProcRoot)
+		switch {
+		case settingsErr != nil:
+			// The settings are provided as context only, so we note the
+			// problem without changing the service check state.
+			logger.Warn().Err(settingsErr).Msg("Failed to retrieve kernel hung task settings")
+			plugin.AddError(settingsErr)
+
+		default:
+			hungTaskSettings = &settings
+		}
+	}
+
+	if cfg.KernelThreads == config.KernelThreadsExclude {
+		logger.Debug().
+			Int("kernel_threads", processes.KernelThreads().Count()).
+			Msg("Excluding kernel threads")
+		processes = processes.Userland()
+	}
+
+	processes, ignored := processes.Ignore(cfg.IgnoreRules())
 
 	logger.Debug().
 		Int("processes", len(processes)).
-		Msg("Excluded process of current tool")
+		Int("ignored_processes", len(ignored)).
+		Msg("Applied ignore rules")
+
+	expectedStates := procstate.SupportedProcessStates()
+	var kernelRelease string
+	kr, krErr := procstate.ParseKernelRelease(snapshot.KernelRelease)
+	switch {
+	case krErr != nil:
+		// Without the kernel release we fall back to accepting any process
+		// state known to this package.
+		logger.Warn().Err(krErr).Msg("Failed to determine kernel release")
+
+	default:
+		kernelRelease = kr.String()
+		expectedStates = kr.ExpectedProcessStates()
+
+		logger.Debug().
+			Str("kernel_release", kernelRelease).
+			Str("kernel_generation", kr.KernelGeneration()).
+			Int("expected_states", len(expectedStates)).
+			Msg("Determined expected process states for kernel release")
+	}
+
+	unexpected := processes.UnexpectedStates(expectedStates)
 
-	pd := getPerfData(processes)
+	logger.Debug().
+		Int("unexpected_state_processes", len(unexpected)).
+		Msg("Evaluated processes for unexpected states")
+
+	summary := processes.Summary(procstate.SummaryOptions{
+		Ignored:                 ignored,
+		Unexpected:              unexpected,
+		UnexpectedStateSeverity: cfg.UnexpectedStateServiceState(),
+	})
+
+	pd := getPerfData(summary)
 	if err := plugin.AddPerfData(false, pd...); err != nil {
 		logger.Error().
 			Err(err).
@@ -133,21 +302,47 @@ func main() {
 		)
 	}
 
+	reportOpts := reports.ReportOptions{
+		Ignored:               ignored,
+		HungTaskSettings:      hungTaskSettings,
+		SeparateKernelThreads: cfg.KernelThreads == config.KernelThreadsSeparate,
+		KernelRelease:         kernelRelease,
+		UnexpectedStates:      unexpected,
+		Snapshot:              &snapshot,
+	}
+
+	serviceState := summary.ServiceState()
+
 	switch {
-	case !processes.IsOKState():
+	case !summary.IsOKState():
 
 		logger.Debug().
-			Int("critical_processes", processes.NumCriticalState()).
-			Int("warning_processes", processes.NumWarningState()).
-			Int("ok_processes", processes.NumOKState()).
+			Int("critical_processes", summary.Critical).
+			Int("warning_processes", summary.Warning).
+			Int("ok_processes", summary.OK).
 			Msg("Problematic processes found")
 
-		plugin.AddError(process.ErrProblemProcessesFound)
+		plugin.AddError(procstate.ErrProblemProcessesFound)
+
+		plugin.ExitStatusCode = serviceState.ExitCode
+
+		plugin.ServiceOutput = reports.CheckProcessOneLineSummary(summary)
+		plugin.LongServiceOutput = reports.CheckProcessReport(processes, summary, reportOpts)
+
+		return
+
+	case summary.Unexpected > 0:
+
+		logger.Debug().
+			Int("unexpected_state_processes", summary.Unexpected).
+			Msg("Processes in unexpected states found")
+
+		plugin.AddError(procstate.ErrUnexpectedProcessStatesFound)
 
-		plugin.ExitStatusCode = processes.ServiceState().ExitCode
+		plugin.ExitStatusCode = serviceState.ExitCode
 
-		plugin.ServiceOutput = reports.CheckProcessOneLineSummary(processes)
-		plugin.LongServiceOutput = reports.CheckProcessReport(processes)
+		plugin.ServiceOutput = reports.CheckProcessOneLineSummary(summary)
+		plugin.LongServiceOutput = reports.CheckProcessReport(processes, summary, reportOpts)
 
 		return
 
@@ -155,10 +350,10 @@ func main() {
 
 		logger.Debug().Msg("No problematic processes detected")
 
-		plugin.ExitStatusCode = processes.ServiceState().ExitCode
+		plugin.ExitStatusCode = serviceState.ExitCode
 
-		plugin.ServiceOutput = reports.CheckProcessOneLineSummary(processes)
-		plugin.LongServiceOutput = reports.CheckProcessReport(processes)
+		plugin.ServiceOutput = reports.CheckProcessOneLineSummary(summary)
+		plugin.LongServiceOutput = reports.CheckProcessReport(processes, summary, reportOpts)
 
 		return
 
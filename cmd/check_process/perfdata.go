@@ -10,65 +10,47 @@ package main
 import (
 	"fmt"
 
-	"github.com/atc0005/check-process/internal/process"
+	"github.com/atc0005/check-process/pkg/procstate"
 	"github.com/atc0005/go-nagios"
 )
 
-// getPerfData gathers performance data metrics that we wish to report.
-func getPerfData(processes process.Processes) []nagios.PerformanceData {
+// getPerfData gathers performance data metrics that we wish to report. The
+// metrics are generated from the evaluation summary; ignored processes are
+// not included in the per-state metrics.
+func getPerfData(summary procstate.Summary) []nagios.PerformanceData {
 
-	probProcs := processes.States(process.KnownProblemProcessStates())
-
-	return []nagios.PerformanceData{
+	pd := []nagios.PerformanceData{
 		// The `time` (runtime) metric is appended at plugin exit, so do not
 		// duplicate it here.
 		{
 			Label: "problem_processes",
-			Value: fmt.Sprintf("%d", len(probProcs)),
-		},
-		{
-			Label: "running",
-			Value: fmt.Sprintf("%d", processes.StateRunningCount()),
-		},
-		{
-			Label: "sleeping",
-			Value: fmt.Sprintf("%d", processes.StateSleepingCount()),
-		},
-		{
-			Label: "uninterruptible_disk_sleep",
-			Value: fmt.Sprintf("%d", processes.StateDiskSleepCount()),
-		},
-		{
-			Label: "stopped",
-			Value: fmt.Sprintf("%d", processes.StateStoppedCount()),
+			Value: fmt.Sprintf("%d", summary.Problem),
 		},
 		{
-			Label: "zombie",
-			Value: fmt.Sprintf("%d", processes.StateZombieCount()),
+			Label: "kernel_problem_processes",
+			Value: fmt.Sprintf("%d", summary.KernelThreadProblem),
 		},
 		{
-			Label: "dead",
-			Value: fmt.Sprintf("%d", processes.StateDeadCount()),
+			Label: "userland_problem_processes",
+			Value: fmt.Sprintf("%d", summary.UserlandProblem),
 		},
 		{
-			Label: "tracing_stop",
-			Value: fmt.Sprintf("%d", processes.StateTracingStopCount()),
+			Label: "unexpected_states",
+			Value: fmt.Sprintf("%d", summary.Unexpected),
 		},
 		{
-			Label: "wakekill",
-			Value: fmt.Sprintf("%d", processes.StateWakeKillCount()),
-		},
-		{
-			Label: "waking",
-			Value: fmt.Sprintf("%d", processes.StateWakingCount()),
-		},
-		{
-			Label: "idle",
-			Value: fmt.Sprintf("%d", processes.StateIdleCount()),
-		},
-		{
-			Label: "parked",
-			Value: fmt.Sprintf("%d", processes.StateParkedCount()),
+			Label: "ignored",
+			Value: fmt.Sprintf("%d", summary.Ignored),
 		},
 	}
+
+	// Emit a metric for each known process state category.
+	for _, tally := range summary.Categories {
+		pd = append(pd, nagios.PerformanceData{
+			Label: tally.Category.PerfDataLabel(),
+			Value: fmt.Sprintf("%d", tally.Count),
+		})
+	}
+
+	return pd
 }
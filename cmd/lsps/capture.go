@@ -0,0 +1,61 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/rs/zerolog"
+)
+
+// captureStdout is the capture command output file argument used to write
+// the archive to stdout.
+const captureStdout string = "-"
+
+// runCapture captures the proc filesystem to the archive file specified as
+// the capture command argument. If not specified, a filename based on the
+// hostname and current time is used. The process of this tool is not
+// captured.
+func runCapture(cfg *config.Config, logger zerolog.Logger) error {
+	filename := defaultCaptureFilename(time.Now())
+	if len(cfg.InspectorSettings.CommandArgs) > 0 {
+		filename = cfg.InspectorSettings.CommandArgs[0]
+	}
+
+	logger.Debug().
+		Str("base_path", procstate.ProcRootDir).
+		Str("archive", filename).
+		Msg("Capturing proc filesystem")
+
+	if filename == captureStdout {
+		return procstate.CaptureArchive(os.Stdout, procstate.ProcRootDir, os.Getpid())
+	}
+
+	if err := procstate.CaptureArchiveFile(filename, procstate.ProcRootDir, os.Getpid()); err != nil {
+		return err
+	}
+
+	fmt.Printf("Captured proc filesystem to %s\n", filename)
+
+	return nil
+}
+
+// defaultCaptureFilename returns the archive filename used by the capture
+// command if one is not specified.
+func defaultCaptureFilename(t time.Time) string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+
+	return fmt.Sprintf("lsps-capture-%s-%s.tar.gz", hostname, t.Format("20060102-150405"))
+}
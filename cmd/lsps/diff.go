@@ -0,0 +1,280 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/rs/zerolog"
+)
+
+// diffMemoryThresholdKB is the minimum change in memory usage (in
+// kilobytes) for a process to be listed as changed by the diff command.
+// Smaller changes are common between samples and are not of interest.
+const diffMemoryThresholdKB int = 1024
+
+// gzipMagic is the header used to identify gzip compressed (capture
+// archive) files.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// runDiff compares the processes from the specified old and new snapshot or
+// capture archive files and writes the differences to the given io.Writer.
+func runDiff(w io.Writer, cfg *config.Config, logger zerolog.Logger, oldFile string, newFile string) error {
+	oldSnapshot, err := loadSnapshot(cfg, logger, oldFile)
+	if err != nil {
+		return err
+	}
+
+	newSnapshot, err := loadSnapshot(cfg, logger, newFile)
+	if err != nil {
+		return err
+	}
+
+	diff := procstate.DiffSnapshots(
+		oldSnapshot,
+		newSnapshot,
+		procstate.DiffOptions{MemoryThresholdKB: diffMemoryThresholdKB},
+	)
+
+	logger.Debug().
+		Int("appeared", len(diff.Appeared)).
+		Int("disappeared", len(diff.Disappeared)).
+		Int("changed", len(diff.Changed)).
+		Msg("Compared processes")
+
+	writeDiff(w, diff, oldFile, oldSnapshot, newFile, newSnapshot)
+
+	return nil
+}
+
+// loadSnapshot reads a snapshot from the specified file. Capture archive
+// files are extracted to a temporary directory and evaluated; other files
+// are read as snapshot JSON (as written by the json output format with the
+// show-all flag). Kernel
+// threads and processes not matching the user-specified filters are
+// removed if requested.
+func loadSnapshot(cfg *config.Config, logger zerolog.Logger, filename string) (procstate.Snapshot, error) {
+	isArchive, err := isArchiveFile(filename)
+	if err != nil {
+		return procstate.Snapshot{}, err
+	}
+
+	var snapshot procstate.Snapshot
+
+	switch {
+	case isArchive:
+		logger.Debug().
+			Str("archive", filename).
+			Msg("Extracting capture archive")
+
+		procRoot, cleanup, err := procstate.ExtractArchiveFile(filename)
+		if err != nil {
+			return procstate.Snapshot{}, err
+		}
+		defer cleanup()
+
+		collector, err := procstate.NewCollector(procstate.WithProcRoot(procRoot))
+		if err != nil {
+			return procstate.Snapshot{}, err
+		}
+
+		snapshot, err = collector.Snapshot()
+		if err != nil {
+			return procstate.Snapshot{}, fmt.Errorf(
+				"failed to evaluate capture archive %s: %w",
+				filename,
+				err,
+			)
+		}
+
+	// Cgroup paths are read from the proc filesystem on demand and are not
+	// recorded in snapshot JSON files. Evaluating the cgroup filter would
+	// read the proc filesystem of the system running this tool instead.
+	case len(cfg.InspectorSettings.FilterCgroups) > 0:
+		return procstate.Snapshot{}, fmt.Errorf(
+			"%w: %s flag cannot be used with snapshot JSON file %s;"+
+				" compare capture archive files instead",
+			config.ErrUnsupportedOption,
+			config.CgroupFlagLong,
+			filename,
+		)
+
+	// Usernames would be resolved using the user database of the system
+	// running this tool instead of the system the snapshot was captured
+	// from.
+	case slices.ContainsFunc(cfg.InspectorSettings.FilterUsers, isUsername):
+		return procstate.Snapshot{}, fmt.Errorf(
+			"%w: %s flag only supports numeric user IDs with snapshot JSON file %s",
+			config.ErrUnsupportedOption,
+			config.UserFlagLong,
+			filename,
+		)
+
+	default:
+		logger.Debug().
+			Str("snapshot", filename).
+			Msg("Reading snapshot JSON file")
+
+		snapshot, err = procstate.ReadSnapshotFile(filename)
+		if err != nil {
+			return procstate.Snapshot{}, err
+		}
+
+		// Processes are only listed in JSON output generated with the
+		// show-all flag.
+		if snapshot.Processes == nil {
+			return procstate.Snapshot{}, fmt.Errorf(
+				"snapshot JSON file %s does not list processes;"+
+					" generate JSON output using the %s flag",
+				filename,
+				config.ShowAllProcessesFlagLong,
+			)
+		}
+	}
+
+	for _, snapshotErr := range snapshot.Errors {
+		logger.Warn().
+			Str("file", filename).
+			Err(snapshotErr).
+			Msg("Snapshot recorded error")
+	}
+
+	if cfg.KernelThreads == config.KernelThreadsExclude {
+		snapshot.Processes = snapshot.Processes.Userland()
+	}
+
+	if filters := cfg.Filters(); len(filters) > 0 {
+		snapshot.Processes = snapshot.Processes.Filter(procstate.And(filters...))
+	}
+
+	return snapshot, nil
+}
+
+// isArchiveFile indicates whether the specified file is a (gzip
+// compressed) capture archive file. Other files are assumed to be snapshot
+// JSON files.
+func isArchiveFile(filename string) (bool, error) {
+	f, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", filename, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	header, err := bufio.NewReader(f).Peek(len(gzipMagic))
+
+	return err == nil && bytes.Equal(header, gzipMagic), nil
+}
+
+// writeDiff writes the given Diff of the specified old and new snapshots to
+// the specified io.Writer.
+func writeDiff(
+	w io.Writer,
+	diff procstate.Diff,
+	oldFile string,
+	oldSnapshot procstate.Snapshot,
+	newFile string,
+	newSnapshot procstate.Snapshot,
+) {
+	_, _ = fmt.Fprintf(w, "Comparing:\n\n")
+	_, _ = fmt.Fprintf(w, "  - old: %s [Hostname: %s, Processes: %d]\n", oldFile, oldSnapshot.Hostname, len(oldSnapshot.Processes))
+	_, _ = fmt.Fprintf(w, "  - new: %s [Hostname: %s, Processes: %d]\n", newFile, newSnapshot.Hostname, len(newSnapshot.Processes))
+
+	_, _ = fmt.Fprintf(w, "\nState changes:\n\n")
+	changedStates := diff.ChangedStates()
+	switch {
+	case len(changedStates) > 0:
+		for _, delta := range changedStates {
+			_, _ = fmt.Fprintf(w, "  - %s [%d -> %d, %+d]\n", delta.State, delta.Old, delta.New, delta.Delta())
+		}
+	default:
+		_, _ = fmt.Fprintf(w, "  - None\n")
+	}
+
+	_, _ = fmt.Fprintf(w, "\nAppeared processes:\n\n")
+	writeDiffProcesses(w, diff.Appeared, newSnapshot.Processes)
+
+	_, _ = fmt.Fprintf(w, "\nDisappeared processes:\n\n")
+	writeDiffProcesses(w, diff.Disappeared, oldSnapshot.Processes)
+
+	_, _ = fmt.Fprintf(w, "\nChanged processes:\n\n")
+	switch {
+	case len(diff.Changed) > 0:
+		for _, change := range diff.Changed {
+			writeProcessChangeLine(w, change)
+		}
+	default:
+		_, _ = fmt.Fprintf(w, "  - None\n")
+	}
+}
+
+// writeDiffProcesses writes the given Process values to the specified
+// io.Writer. The complete collection the processes were found in is
+// provided in order to resolve parent processes.
+func writeDiffProcesses(w io.Writer, processes procstate.Processes, all procstate.Processes) {
+	if len(processes) == 0 {
+		_, _ = fmt.Fprintf(w, "  - None\n")
+
+		return
+	}
+
+	for _, p := range processes {
+		writeProcessInfoLine(w, p, all, true)
+	}
+}
+
+// writeProcessChangeLine writes the given ProcessChange to the specified
+// io.Writer in a one-line format listing the old and new values of each
+// changed field.
+func writeProcessChangeLine(w io.Writer, change procstate.ProcessChange) {
+	details := make([]string, 0, len(change.Fields))
+	for _, field := range change.Fields {
+		var oldValue, newValue any
+		switch field {
+		case procstate.DiffFieldState:
+			oldValue, newValue = change.Old.State, change.New.State
+		case procstate.DiffFieldPPid:
+			oldValue, newValue = change.Old.PPid, change.New.PPid
+		case procstate.DiffFieldThreads:
+			oldValue, newValue = change.Old.Threads, change.New.Threads
+		case procstate.DiffFieldVMRSS:
+			oldValue, newValue = change.Old.VMRSS, change.New.VMRSS
+		case procstate.DiffFieldVMSwap:
+			oldValue, newValue = change.Old.VMSwap, change.New.VMSwap
+		}
+
+		details = append(details, fmt.Sprintf("%s: %v -> %v", field, oldValue, newValue))
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  - Name: %10s [Pid: %v, %s]\n",
+		change.New.Name,
+		change.New.Pid,
+		strings.Join(details, ", "),
+	)
+}
+
+// isUsername indicates whether the given user filter value is a username
+// instead of a numeric user ID.
+func isUsername(value string) bool {
+	_, err := strconv.Atoi(value)
+
+	return err != nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/rs/zerolog"
+)
+
+// TestLoadSnapshotJSON asserts that filters are applied to processes read
+// from a snapshot JSON file and that the cgroup filter and usernames are
+// rejected instead of being evaluated against the current system.
+func TestLoadSnapshotJSON(t *testing.T) {
+	t.Parallel()
+
+	// The recorded proc directory of the shell is the proc directory of
+	// this test process, which is present on the current system.
+	snapshot := procstate.Snapshot{
+		FormatVersion: procstate.SnapshotFormatVersion,
+		Hostname:      "captured-host",
+		Processes: procstate.Processes{
+			{Name: "bash", Pid: 100, PPid: 1, State: procstate.KernelAnyProcessStateSleeping,
+				ProcDir: filepath.Join(procstate.ProcRootDir, strconv.Itoa(os.Getpid()))},
+			{Name: "java", Pid: 200, PPid: 100, State: procstate.KernelAnyProcessStateDiskSleep,
+				ProcDir:       filepath.Join(procstate.ProcRootDir, "self"),
+				AllProperties: procstate.Properties{procstate.ProcessUIDField: "501\t501\t501\t501"}},
+		},
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		t.Fatalf("failed to write snapshot file: %v", err)
+	}
+
+	tests := map[string]struct {
+		cfg      config.Config
+		wantPids []int
+		wantErr  error
+	}{
+		"no filters": {
+			wantPids: []int{100, 200},
+		},
+		"name filter": {
+			cfg: func() config.Config {
+				var cfg config.Config
+				cfg.InspectorSettings.FilterNames = []string{"java"}
+
+				return cfg
+			}(),
+			wantPids: []int{200},
+		},
+		"numeric user filter": {
+			cfg: func() config.Config {
+				var cfg config.Config
+				cfg.InspectorSettings.FilterUsers = []string{"501"}
+
+				return cfg
+			}(),
+			wantPids: []int{200},
+		},
+		"username filter": {
+			cfg: func() config.Config {
+				var cfg config.Config
+				cfg.InspectorSettings.FilterUsers = []string{"501", "root"}
+
+				return cfg
+			}(),
+			wantErr: config.ErrUnsupportedOption,
+		},
+		"cgroup filter": {
+			cfg: func() config.Config {
+				var cfg config.Config
+				cfg.InspectorSettings.FilterCgroups = []string{"/"}
+
+				return cfg
+			}(),
+			wantErr: config.ErrUnsupportedOption,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := loadSnapshot(&tt.cfg, zerolog.Nop(), filename)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error wrapping %v, got %v", tt.wantErr, err)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			pids := make([]int, 0, len(got.Processes))
+			for _, p := range got.Processes {
+				pids = append(pids, p.Pid)
+			}
+
+			if !slices.Equal(pids, tt.wantPids) {
+				t.Errorf("\nwant pids %v\ngot pids  %v", tt.wantPids, pids)
+			}
+		})
+	}
+}
+
+// TestLoadSnapshotJSONWithoutProcesses asserts that a snapshot JSON file
+// which does not list processes (i.e., JSON output generated without the
+// show-all flag) is rejected instead of being compared as an empty
+// collection.
+func TestLoadSnapshotJSONWithoutProcesses(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(procstate.Snapshot{FormatVersion: procstate.SnapshotFormatVersion})
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		t.Fatalf("failed to write snapshot file: %v", err)
+	}
+
+	if _, err := loadSnapshot(&config.Config{}, zerolog.Nop(), filename); err == nil {
+		t.Errorf("want error for snapshot JSON file without processes, got nil")
+	}
+}
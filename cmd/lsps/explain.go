@@ -0,0 +1,111 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/atc0005/go-nagios"
+)
+
+// kernelGenerationReleases maps the baseline kernel versions to the RHEL
+// releases they were observed on.
+var kernelGenerationReleases = map[string]string{
+	procstate.KernelGeneration2632: "RHEL 6",
+	procstate.KernelGeneration310:  "RHEL 7",
+	procstate.KernelGeneration418:  "RHEL 8",
+	procstate.KernelGeneration514:  "RHEL 9",
+}
+
+// writeStateExplanations writes every process state known to this tool to
+// the specified io.Writer along with the kernel versions which emit it, an
+// explanation and the severity check_process would assign using the given
+// configuration. The kernel release is read from the specified proc
+// filesystem root.
+func writeStateExplanations(w io.Writer, cfg *config.Config, procRoot string) {
+	var expected []procstate.ProcessState
+
+	kr, err := procstate.GetKernelRelease(procRoot)
+	switch {
+	case err != nil:
+		_, _ = fmt.Fprintf(w, "Kernel release: unavailable (%v)\n", err)
+		_, _ = fmt.Fprintf(w, "All known states are treated as expected.\n")
+		expected = procstate.SupportedProcessStates()
+
+	default:
+		_, _ = fmt.Fprintf(
+			w,
+			"Kernel release: %s (evaluated as %s)\n",
+			kr,
+			kernelGenerationLabel(kr.KernelGeneration()),
+		)
+		expected = kr.ExpectedProcessStates()
+	}
+
+	unexpectedServiceState := cfg.UnexpectedStateServiceState()
+	_, _ = fmt.Fprintf(
+		w,
+		"States not expected for this kernel are reported as %s (%s flag).\n",
+		unexpectedServiceState.Label,
+		config.UnexpectedStateSeverityFlagLong,
+	)
+
+	for _, state := range procstate.SupportedProcessStates() {
+		kernels := make([]string, 0, len(state.Kernels))
+		for _, kernel := range state.Kernels {
+			kernels = append(kernels, kernelGenerationLabel(kernel))
+		}
+
+		serviceState, reason := explainStateSeverity(state, expected, unexpectedServiceState)
+
+		_, _ = fmt.Fprintf(w, "\n%s\n\n", state)
+		_, _ = fmt.Fprintf(w, "  - Kernels: %s\n", strings.Join(kernels, ", "))
+		_, _ = fmt.Fprintf(w, "  - Category: %s\n", state.Category)
+		_, _ = fmt.Fprintf(w, "  - Severity: %s (%s)\n", serviceState.Label, reason)
+		_, _ = fmt.Fprintf(w, "  - Explanation: %s\n", state.Explanation)
+	}
+}
+
+// explainStateSeverity returns the service state check_process would assign
+// for processes in the given state along with the reason. The state is
+// evaluated against the specified list of expected states.
+func explainStateSeverity(
+	state procstate.ProcessState,
+	expected []procstate.ProcessState,
+	unexpectedServiceState nagios.ServiceState,
+) (nagios.ServiceState, string) {
+	stateServiceState := state.ServiceState()
+
+	for _, e := range expected {
+		if e.String() == state.String() {
+			if state.Severity == procstate.SeverityOK {
+				return stateServiceState, "not a problem state"
+			}
+
+			return stateServiceState, "problem state"
+		}
+	}
+
+	return procstate.WorstServiceState(stateServiceState, unexpectedServiceState),
+		"not expected for this kernel"
+}
+
+// kernelGenerationLabel returns the given baseline kernel version along with
+// the RHEL release it was observed on (if known).
+func kernelGenerationLabel(kernel string) string {
+	release, ok := kernelGenerationReleases[kernel]
+	if !ok {
+		return kernel
+	}
+
+	return fmt.Sprintf("%s (%s)", kernel, release)
+}
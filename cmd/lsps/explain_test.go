@@ -0,0 +1,74 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// TestWriteStateExplanations asserts that the state explanation table
+// written for a kernel release matches the golden file for it. The golden
+// files list the kernels, category, severity and explanation of every known
+// state and need to be updated along with the known process state table.
+func TestWriteStateExplanations(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		kernelRelease string
+		severity      string
+		golden        string
+	}{
+		"rhel6 kernel with critical unexpected state severity": {
+			kernelRelease: "2.6.32-754.el6.x86_64",
+			severity:      config.SeverityCritical,
+			golden:        "rhel6-critical.golden",
+		},
+		"rhel8 kernel with default unexpected state severity": {
+			kernelRelease: "4.18.0-513.5.1.el8_9.x86_64",
+			golden:        "rhel8-default.golden",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			procRoot := t.TempDir()
+
+			filename := filepath.Join(procRoot, procstate.ProcKernelReleaseFilename)
+			if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+				t.Fatalf("failed to create directory: %v", err)
+			}
+
+			if err := os.WriteFile(filename, []byte(tt.kernelRelease+"\n"), 0o600); err != nil {
+				t.Fatalf("failed to create proc file: %v", err)
+			}
+
+			var cfg config.Config
+			cfg.UnexpectedStateSeverity = tt.severity
+
+			var buf bytes.Buffer
+			writeStateExplanations(&buf, &cfg, procRoot)
+
+			want, err := os.ReadFile(filepath.Join("testdata", "explain", tt.golden))
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if got := buf.Bytes(); !bytes.Equal(got, want) {
+				t.Errorf("\nwant %s\ngot  %s", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/internal/textutils"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/atc0005/go-nagios"
+)
+
+// writeProcessDetails collects processes using the given Collector and
+// writes everything known about each of the requested processes to the
+// specified io.Writer. Details of the error are written for requested
+// processes which could not be evaluated. An error is returned for
+// requested processes which were not found or could not be evaluated after
+// details for all other processes are written.
+func writeProcessDetails(w io.Writer, cfg *config.Config, collector *procstate.Collector) error {
+	snapshot, err := collector.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	pidIndex := make(map[int]procstate.Process, len(snapshot.Processes))
+	for _, p := range snapshot.Processes {
+		pidIndex[p.Pid] = p
+	}
+
+	errIndex := make(map[int]procstate.SnapshotError)
+	for _, procErr := range snapshot.ProcessErrors() {
+		errIndex[procErr.Pid] = procErr
+	}
+
+	var errs []error
+	for i, pid := range cfg.InspectorSettings.Pids {
+		if i > 0 {
+			_, _ = fmt.Fprintf(w, "\n%s\n\n", strings.Repeat("-", 50))
+		}
+
+		if procErr, found := errIndex[pid]; found {
+			writeProcessError(w, procErr)
+			errs = append(errs, procErr)
+
+			continue
+		}
+
+		p, ok := pidIndex[pid]
+		if !ok {
+			_, _ = fmt.Fprintf(w, "Process %d:\n\n  - Not found\n", pid)
+			errs = append(errs, fmt.Errorf("process %d: %w", pid, procstate.ErrMissingProcessEntry))
+
+			continue
+		}
+
+		writeProcessDetail(w, cfg, p, snapshot)
+	}
+
+	return errors.Join(errs...)
+}
+
+// writeProcessError writes the details of the given error recorded for a
+// process which could not be evaluated to the specified io.Writer. The
+// location and offending content are written for parse errors.
+func writeProcessError(w io.Writer, procErr procstate.SnapshotError) {
+	_, _ = fmt.Fprintf(w, "Process %d:\n\n", procErr.Pid)
+	_, _ = fmt.Fprintf(w, "  - Could not be evaluated: %s\n", textutils.Printable(procErr.Message))
+
+	var parseErr *procstate.ParseError
+	if !errors.As(procErr, &parseErr) {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\nParse error:\n\n")
+	if parseErr.Path != "" {
+		_, _ = fmt.Fprintf(w, "  - File: %s\n", textutils.Printable(parseErr.Path))
+	}
+	if parseErr.Line > 0 {
+		_, _ = fmt.Fprintf(w, "  - Line: %d\n", parseErr.Line)
+	}
+	if parseErr.Key != "" {
+		_, _ = fmt.Fprintf(w, "  - Key: %s\n", textutils.Printable(parseErr.Key))
+	}
+	_, _ = fmt.Fprintf(w, "  - Value: %q\n", parseErr.Value)
+	if parseErr.Err != nil {
+		_, _ = fmt.Fprintf(w, "  - Reason: %s\n", textutils.Printable(parseErr.Err.Error()))
+	}
+}
+
+// writeProcessDetail writes everything known about the given Process to the
+// specified io.Writer. The snapshot the process was found in is provided in
+// order to resolve related processes and the running kernel release.
+func writeProcessDetail(w io.Writer, cfg *config.Config, p procstate.Process, snapshot procstate.Snapshot) {
+	// Values such as the command line are controlled by the process owner
+	// and are escaped so that they cannot inject terminal escape sequences.
+	_, _ = fmt.Fprintf(w, "Process %d (%s):\n", p.Pid, textutils.Printable(p.Name))
+
+	serviceState, reason := explainSeverity(cfg, p, snapshot.KernelRelease)
+	_, _ = fmt.Fprintf(w, "\nSeverity:\n\n")
+	_, _ = fmt.Fprintf(w, "  - %s: %s\n", serviceState.Label, reason)
+	_, _ = fmt.Fprintf(w, "  - Ignore rules of check_process (%s and related flags) are not evaluated\n", config.IgnoreNameFlagLong)
+
+	_, _ = fmt.Fprintf(w, "\nProperties:\n\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, key := range slices.Sorted(maps.Keys(p.AllProperties)) {
+		_, _ = fmt.Fprintf(tw, "  %s:\t%s\n", key, textutils.Printable(p.AllProperties[key]))
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "\nSignal masks:\n\n")
+	for _, field := range procstate.SignalMaskFields() {
+		mask, ok := p.AllProperties[field]
+		if !ok {
+			continue
+		}
+
+		signals, err := procstate.DecodeSignalMask(mask)
+		switch {
+		case err != nil:
+			_, _ = fmt.Fprintf(w, "  - %s: %s (%v)\n", field, mask, err)
+		case len(signals) == 0:
+			_, _ = fmt.Fprintf(w, "  - %s: %s (none)\n", field, mask)
+		default:
+			_, _ = fmt.Fprintf(w, "  - %s: %s (%s)\n", field, mask, strings.Join(signals, ", "))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\nAncestors (nearest parent first):\n\n")
+	writeRelatedProcesses(w, ancestorChain(p, snapshot.Processes))
+
+	_, _ = fmt.Fprintf(w, "\nChildren:\n\n")
+	writeRelatedProcesses(w, snapshot.Processes.Children(p))
+
+	_, _ = fmt.Fprintf(w, "\nCgroups:\n\n")
+	cgroups, err := p.Cgroups()
+	switch {
+	case err != nil:
+		_, _ = fmt.Fprintf(w, "  - Unavailable: %v\n", err)
+	case len(cgroups) == 0:
+		_, _ = fmt.Fprintf(w, "  - None\n")
+	default:
+		for _, cgroup := range cgroups {
+			_, _ = fmt.Fprintf(w, "  - %s\n", textutils.Printable(cgroup))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\nLimits:\n\n")
+	limits, err := p.Limits()
+	switch {
+	case err != nil:
+		_, _ = fmt.Fprintf(w, "  - Unavailable: %v\n", err)
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "  LIMIT\tSOFT\tHARD\tUNITS\n")
+		for _, limit := range limits {
+			_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", limit.Name, limit.Soft, limit.Hard, limit.Units)
+		}
+		_ = tw.Flush()
+	}
+
+	_, _ = fmt.Fprintf(w, "\nOther details:\n\n")
+
+	fdCount := "unavailable"
+	if n, err := p.FDCount(); err == nil {
+		fdCount = strconv.Itoa(n)
+	}
+	_, _ = fmt.Fprintf(w, "  - Open file descriptors: %s\n", fdCount)
+
+	wchan, err := p.Wchan()
+	switch {
+	case err != nil:
+		wchan = "unavailable"
+	case wchan == "" || wchan == "0":
+		wchan = "none"
+	}
+	_, _ = fmt.Fprintf(w, "  - Wait channel (wchan): %s\n", textutils.Printable(wchan))
+
+	cmdline, err := p.Cmdline()
+	switch {
+	case err != nil:
+		cmdline = "unavailable"
+	case cmdline == "":
+		cmdline = "[" + p.Name + "]"
+	}
+	_, _ = fmt.Fprintf(w, "  - Command line: %s\n", textutils.Printable(cmdline))
+	_, _ = fmt.Fprintf(w, "  - Kernel thread: %t\n", p.IsKernelThread())
+	_, _ = fmt.Fprintf(w, "  - CPU time: %s\n", procstate.ClockTicksToDuration(p.CPUTime))
+
+	if !snapshot.BootTime.IsZero() {
+		_, _ = fmt.Fprintf(w, "  - Started: %s\n", p.StartedAt(snapshot.BootTime).Format(time.DateTime))
+	}
+}
+
+// explainSeverity returns the service state check_process would assign for
+// the given Process along with the reason. The process state is evaluated
+// against the states expected for the specified kernel release.
+func explainSeverity(cfg *config.Config, p procstate.Process, kernelRelease string) (nagios.ServiceState, string) {
+	expected := procstate.SupportedProcessStates()
+	if kr, err := procstate.ParseKernelRelease(kernelRelease); err == nil {
+		expected = kr.ExpectedProcessStates()
+	}
+
+	stateServiceState := p.State.ServiceState()
+
+	switch {
+	case !p.IsExpectedState(expected):
+		unexpectedServiceState := cfg.UnexpectedStateServiceState()
+
+		reason := fmt.Sprintf("state %s is not known to this tool", p.State)
+		if p.State.IsKnown() {
+			reason = fmt.Sprintf("state %s is not expected for kernel release %s", p.State, kernelRelease)
+		}
+
+		return procstate.WorstServiceState(stateServiceState, unexpectedServiceState),
+			fmt.Sprintf(
+				"%s; processes in unexpected states are reported as %s (%s flag)",
+				reason,
+				unexpectedServiceState.Label,
+				config.UnexpectedStateSeverityFlagLong,
+			)
+
+	case p.IsOKState():
+		return stateServiceState, fmt.Sprintf("state %s is not a problem state", p.State)
+
+	default:
+		return stateServiceState, fmt.Sprintf(
+			"state %s is a problem state (category %s) mapped to %s",
+			p.State,
+			p.State.Category,
+			stateServiceState.Label,
+		)
+	}
+}
+
+// ancestorChain returns the chain of parent processes from the collection
+// for the specified Process, nearest parent first. Unlike the Ancestors
+// method, the init process and kernel thread daemon are included.
+func ancestorChain(p procstate.Process, processes procstate.Processes) procstate.Processes {
+	var chain procstate.Processes
+	seen := map[int]struct{}{p.Pid: {}}
+
+	current := p
+	for {
+		parent, err := processes.ParentProcess(current)
+		if err != nil {
+			return chain
+		}
+
+		// Guard against cycles (e.g., PID 0 as the parent of init).
+		if _, ok := seen[parent.Pid]; ok {
+			return chain
+		}
+		seen[parent.Pid] = struct{}{}
+
+		chain = append(chain, parent)
+		current = parent
+	}
+}
+
+// writeRelatedProcesses writes a line for each of the given related Process
+// values to the specified io.Writer.
+func writeRelatedProcesses(w io.Writer, processes procstate.Processes) {
+	if len(processes) == 0 {
+		_, _ = fmt.Fprintf(w, "  - None\n")
+
+		return
+	}
+
+	for _, p := range processes {
+		_, _ = fmt.Fprintf(w, "  - %s [Pid: %d, State: %s]\n", textutils.Printable(p.Name), p.Pid, p.State)
+	}
+}
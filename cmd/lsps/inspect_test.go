@@ -0,0 +1,231 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/atc0005/go-nagios"
+)
+
+// writeTestProcess creates a /proc/[pid] directory within the specified
+// proc root using the status and stat testdata files of the procstate
+// package with the given base name and returns the path to it.
+func writeTestProcess(t *testing.T, procRoot string, pid int, fixture string) string {
+	t.Helper()
+
+	procDir := filepath.Join(procRoot, strconv.Itoa(pid))
+	if err := os.Mkdir(procDir, 0o700); err != nil {
+		t.Fatalf("failed to create proc directory: %v", err)
+	}
+
+	for _, file := range []struct {
+		src string
+		dst string
+	}{
+		{src: filepath.Join("status", fixture+".status"), dst: procstate.ProcStatusFilename},
+		{src: filepath.Join("stat", fixture+".stat"), dst: procstate.ProcStatFilename},
+	} {
+		data, err := os.ReadFile(filepath.Join("..", "..", "pkg", "procstate", "testdata", file.src))
+		if err != nil {
+			t.Fatalf("failed to read testdata file: %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(procDir, file.dst), data, 0o600); err != nil {
+			t.Fatalf("failed to create proc file: %v", err)
+		}
+	}
+
+	return procDir
+}
+
+// TestWriteProcessDetailsParseError asserts that the details of a parse
+// error are written for a requested process which could not be evaluated
+// instead of reporting the process as not found.
+func TestWriteProcessDetailsParseError(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+	procDir := writeTestProcess(t, procRoot, 4321, "rhel6-disk-sleep")
+
+	statusFile := filepath.Join(procDir, procstate.ProcStatusFilename)
+	status, err := os.ReadFile(statusFile)
+	if err != nil {
+		t.Fatalf("failed to read status file: %v", err)
+	}
+
+	status = bytes.Replace(status, []byte("Threads:\t87"), []byte("Threads:\teighty-seven"), 1)
+	if err := os.WriteFile(statusFile, status, 0o600); err != nil {
+		t.Fatalf("failed to write status file: %v", err)
+	}
+
+	collector, err := procstate.NewCollector(procstate.WithProcRoot(procRoot), procstate.WithAllProperties())
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	var cfg config.Config
+	cfg.InspectorSettings.Pids = []int{4321, 5000}
+
+	var buf bytes.Buffer
+	err = writeProcessDetails(&buf, &cfg, collector)
+
+	var parseErr *procstate.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("want error wrapping parse error, got %v", err)
+	}
+
+	if !errors.Is(err, procstate.ErrMissingProcessEntry) {
+		t.Errorf("want error wrapping %v for missing process, got %v", procstate.ErrMissingProcessEntry, err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"Process 4321:\n\n  - Could not be evaluated: ",
+		"\nParse error:\n\n",
+		"  - File: " + statusFile + "\n",
+		"  - Line: 23\n",
+		"  - Key: threads\n",
+		"  - Value: \"eighty-seven\"\n",
+		"Process 5000:\n\n  - Not found\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+// TestWriteProcessDetailsEscapesCmdline asserts that terminal escape
+// sequences within a command line are escaped when writing the details of
+// a process.
+func TestWriteProcessDetailsEscapesCmdline(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+	procDir := writeTestProcess(t, procRoot, 4321, "rhel6-disk-sleep")
+
+	cmdline := "java\x00\x1b]52;c;cm0gLXJmIH4=\x07\x00-jar\x00app.jar\x00"
+	if err := os.WriteFile(filepath.Join(procDir, procstate.ProcCmdlineFilename), []byte(cmdline), 0o600); err != nil {
+		t.Fatalf("failed to create cmdline file: %v", err)
+	}
+
+	collector, err := procstate.NewCollector(procstate.WithProcRoot(procRoot), procstate.WithAllProperties())
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	var cfg config.Config
+	cfg.InspectorSettings.Pids = []int{4321}
+
+	var buf bytes.Buffer
+	if err := writeProcessDetails(&buf, &cfg, collector); err != nil {
+		t.Fatalf("failed to write process details: %v", err)
+	}
+
+	want := `  - Command line: java \x1b]52;c;cm0gLXJmIH4=\a -jar app.jar` + "\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output does not contain %q:\n%s", want, buf.String())
+	}
+
+	if strings.ContainsRune(buf.String(), '\x1b') {
+		t.Errorf("escape character written to process details")
+	}
+}
+
+// TestExplainSeverity asserts that the service state and reason are
+// provided for known problem states, OK states and states not expected for
+// the kernel release.
+func TestExplainSeverity(t *testing.T) {
+	t.Parallel()
+
+	const rhel8 string = "4.18.0-513.5.1.el8_9.x86_64"
+
+	tests := map[string]struct {
+		state         procstate.ProcessState
+		kernelRelease string
+		severity      string
+		wantLabel     string
+		wantReason    string
+	}{
+		"ok state": {
+			state:         procstate.KernelAnyProcessStateSleeping,
+			kernelRelease: rhel8,
+			wantLabel:     nagios.StateOKLabel,
+			wantReason:    "state S (sleeping) is not a problem state",
+		},
+		"critical problem state": {
+			state:         procstate.KernelAnyProcessStateDiskSleep,
+			kernelRelease: rhel8,
+			wantLabel:     nagios.StateCRITICALLabel,
+			wantReason:    "state D (disk sleep) is a problem state (category uninterruptible_disk_sleep) mapped to CRITICAL",
+		},
+		"warning problem state": {
+			state:         procstate.KernelAnyProcessStateZombie,
+			kernelRelease: rhel8,
+			wantLabel:     nagios.StateWARNINGLabel,
+			wantReason:    "state Z (zombie) is a problem state (category zombie) mapped to WARNING",
+		},
+		"state not expected for kernel release": {
+			state:         procstate.KernelLegacyProcessStateWakeKill,
+			kernelRelease: rhel8,
+			wantLabel:     nagios.StateWARNINGLabel,
+			wantReason: "state K (wakekill) is not expected for kernel release " + rhel8 +
+				"; processes in unexpected states are reported as WARNING (unexpected-state-severity flag)",
+		},
+		"unknown state with configured severity": {
+			state:         procstate.ProcessState{Code: 'Q', Description: "queued"},
+			kernelRelease: rhel8,
+			severity:      config.SeverityCritical,
+			wantLabel:     nagios.StateCRITICALLabel,
+			wantReason: "state Q (queued) is not known to this tool" +
+				"; processes in unexpected states are reported as CRITICAL (unexpected-state-severity flag)",
+		},
+		"problem state more severe than unexpected state severity": {
+			state:         procstate.KernelAnyProcessStateDiskSleep,
+			kernelRelease: "2.6.32-754.el6.x86_64",
+			severity:      config.SeverityOK,
+			wantLabel:     nagios.StateCRITICALLabel,
+			wantReason:    "state D (disk sleep) is a problem state (category uninterruptible_disk_sleep) mapped to CRITICAL",
+		},
+		"unparsable kernel release": {
+			state:         procstate.KernelLegacyProcessStateWakeKill,
+			kernelRelease: "unknown",
+			wantLabel:     nagios.StateOKLabel,
+			wantReason:    "state K (wakekill) is not a problem state",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg config.Config
+			cfg.UnexpectedStateSeverity = tt.severity
+
+			p := procstate.Process{Name: "java", Pid: 4321, PPid: 1, State: tt.state}
+
+			got, reason := explainSeverity(&cfg, p, tt.kernelRelease)
+
+			if got.Label != tt.wantLabel {
+				t.Errorf("want %s, got %s", tt.wantLabel, got.Label)
+			}
+
+			if reason != tt.wantReason {
+				t.Errorf("\nwant %q\ngot  %q", tt.wantReason, reason)
+			}
+		})
+	}
+}
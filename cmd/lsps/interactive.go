@@ -0,0 +1,881 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/atc0005/check-process/internal/textutils"
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/rs/zerolog"
+)
+
+// interactiveRefreshInterval is the interval used to refresh the
+// interactive view.
+const interactiveRefreshInterval time.Duration = 2 * time.Second
+
+// ANSI escape sequences used to control the terminal in interactive mode.
+const (
+	ansiReverse        string = "\x1b[7m"
+	ansiClearLine      string = "\x1b[K"
+	ansiClearBelow     string = "\x1b[J"
+	ansiCursorHome     string = "\x1b[H"
+	ansiEnterAltScreen string = "\x1b[?1049h\x1b[?25l"
+	ansiLeaveAltScreen string = "\x1b[?25h\x1b[?1049l"
+)
+
+// Names of the keys handled in interactive mode. Printable characters are
+// represented as-is.
+const (
+	keyUp        string = "up"
+	keyDown      string = "down"
+	keyPageUp    string = "pgup"
+	keyPageDown  string = "pgdn"
+	keyHome      string = "home"
+	keyEnd       string = "end"
+	keyEnter     string = "enter"
+	keyEscape    string = "esc"
+	keyBackspace string = "backspace"
+	keyInterrupt string = "ctrl-c"
+)
+
+// interactiveNoneText is written in place of an empty list.
+const interactiveNoneText string = "  - None"
+
+// interactiveHeaderLines is the number of lines written above the process
+// table of the interactive view.
+const interactiveHeaderLines int = 5
+
+// interactiveSortKey is a sort order for the process table of the
+// interactive view.
+type interactiveSortKey struct {
+	// name is the name of the sort order shown in the view.
+	name string
+
+	// compare orders rows with the most significant row first.
+	compare func(a interactiveRow, b interactiveRow) int
+}
+
+// interactiveSortKeys is the list of sort orders cycled through in the
+// interactive view. The first entry is the default.
+var interactiveSortKeys = []interactiveSortKey{
+	{
+		name: "state",
+		compare: func(a interactiveRow, b interactiveRow) int {
+			return cmp.Or(
+				procstate.CompareBySeverity(a.process, b.process),
+				procstate.CompareByState(a.process, b.process),
+			)
+		},
+	},
+	{
+		name: "swap",
+		compare: func(a interactiveRow, b interactiveRow) int {
+			return procstate.CompareByVMSwap(b.process, a.process)
+		},
+	},
+	{
+		name: "threads",
+		compare: func(a interactiveRow, b interactiveRow) int {
+			return procstate.CompareByThreads(b.process, a.process)
+		},
+	},
+	{
+		name: "age",
+		compare: func(a interactiveRow, b interactiveRow) int {
+			return cmp.Compare(b.age, a.age)
+		},
+	},
+	{
+		name: "cpu",
+		compare: func(a interactiveRow, b interactiveRow) int {
+			return cmp.Compare(b.cpu, a.cpu)
+		},
+	},
+}
+
+// interactiveRow is a row of the process table of the interactive view.
+type interactiveRow struct {
+	process procstate.Process
+	user    string
+
+	// age is the time since the process started. This value is zero if the
+	// system boot time is not known.
+	age time.Duration
+
+	// cpu is the percentage of a single CPU used by the process since the
+	// previous refresh.
+	cpu float64
+}
+
+// interactiveInput is a value being entered by the user in the interactive
+// view.
+type interactiveInput struct {
+	prompt string
+	value  string
+	target *string
+}
+
+// interactiveLine is a line written to the terminal by the interactive
+// view.
+type interactiveLine struct {
+	text string
+
+	// highlight indicates whether the line is written in reverse video.
+	highlight bool
+}
+
+// interactiveView is the state of the interactive view.
+type interactiveView struct {
+	collector *procstate.Collector
+	isListed  procstate.Predicate
+
+	// snapshot is the most recent collection of all processes; listed is
+	// the subset of those processes matching isListed.
+	snapshot  procstate.Snapshot
+	listed    procstate.Processes
+	sampledAt time.Time
+	cpuTimes  map[procstate.ProcessKey]uint64
+	cpu       map[procstate.ProcessKey]float64
+	rows      []interactiveRow
+
+	showAll    bool
+	sortIdx    int
+	reversed   bool
+	nameFilter string
+	userFilter string
+
+	selected int
+	offset   int
+	pageSize int
+
+	// detail identifies the process shown in the detail view. The process
+	// table is shown if nil.
+	detail       *procstate.ProcessKey
+	detailOffset int
+
+	input   *interactiveInput
+	message string
+}
+
+// runInteractive runs the full-screen interactive view of processes
+// collected using the given Collector until the user quits. Only processes
+// matching the given predicate are listed; of those, problem processes are
+// listed unless all processes are requested.
+func runInteractive(logger zerolog.Logger, collector *procstate.Collector, isListed procstate.Predicate, showAll bool) error {
+	inFd, outFd := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+
+	if _, _, err := terminalSize(outFd); err != nil {
+		return fmt.Errorf("interactive mode requires a terminal: %w", err)
+	}
+
+	restore, err := makeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("interactive mode requires a terminal: %w", err)
+	}
+	defer func() {
+		if err := restore(); err != nil {
+			logger.Error().Err(err).Msg("Failed to restore terminal")
+		}
+	}()
+
+	_, _ = fmt.Fprint(os.Stdout, ansiEnterAltScreen)
+	defer func() {
+		_, _ = fmt.Fprint(os.Stdout, ansiLeaveAltScreen)
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	defer signal.Stop(resize)
+
+	input, closeInput, err := openTerminalInput(inFd)
+	if err != nil {
+		return fmt.Errorf("interactive mode requires a terminal: %w", err)
+	}
+
+	keys := readKeys(input)
+	defer func() {
+		// Interrupt the pending read and wait for the reader to exit.
+		if err := input.SetReadDeadline(time.Now()); err != nil {
+			logger.Error().Err(err).Msg("Failed to interrupt terminal input")
+		}
+		for range keys {
+		}
+
+		if err := closeInput(); err != nil {
+			logger.Error().Err(err).Msg("Failed to close terminal input")
+		}
+	}()
+
+	view := interactiveView{
+		collector: collector,
+		isListed:  isListed,
+		showAll:   showAll,
+		cpuTimes:  make(map[procstate.ProcessKey]uint64),
+		cpu:       make(map[procstate.ProcessKey]float64),
+	}
+
+	ticker := time.NewTicker(interactiveRefreshInterval)
+	defer ticker.Stop()
+
+	view.refresh()
+
+	for {
+		width, height, err := terminalSize(outFd)
+		if err != nil {
+			return err
+		}
+
+		out := bufio.NewWriter(os.Stdout)
+		view.render(out, width, height)
+		if err := out.Flush(); err != nil {
+			return fmt.Errorf("failed to write interactive view: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Debug().Msg("Interactive mode interrupted")
+
+			return nil
+
+		case <-ticker.C:
+			view.refresh()
+
+		case <-resize:
+			// The view is rendered for the new terminal size on the next
+			// iteration.
+
+		case pressed, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			for _, key := range pressed {
+				if quit := view.handleKey(key); quit {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// readKeys reads keyboard input from the specified io.Reader, sending the
+// keys found in each read to the returned channel. Escape sequences split
+// after the introducer and characters split across reads are completed by
+// the following read. The channel is closed
+// once reading fails.
+func readKeys(r io.Reader) <-chan []string {
+	keys := make(chan []string)
+
+	go func() {
+		defer close(keys)
+
+		buf := make([]byte, 64)
+		var pending int
+		for {
+			n, err := r.Read(buf[pending:])
+			if n > 0 {
+				found, used := parseKeys(buf[:pending+n])
+				pending = copy(buf, buf[used:pending+n])
+
+				// An incomplete escape sequence filling the buffer is
+				// discarded.
+				if pending == len(buf) {
+					pending = 0
+				}
+
+				if len(found) > 0 {
+					keys <- found
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return keys
+}
+
+// parseKeys returns the names of the keys found in the given keyboard input
+// and the number of bytes consumed. An incomplete escape sequence or UTF-8
+// encoded character at the end of the input is not consumed. Unrecognized
+// escape sequences, invalid UTF-8 and non-printable characters are ignored.
+func parseKeys(data []byte) ([]string, int) {
+	var keys []string
+
+	for i := 0; i < len(data); {
+		switch b := data[i]; {
+		case b == 0x1b && i+1 < len(data) && (data[i+1] == '[' || data[i+1] == 'O'):
+			// Escape sequences end with a byte in the range 0x40-0x7e.
+			end := i + 2
+			for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+				end++
+			}
+			if end == len(data) {
+				return keys, i
+			}
+
+			switch string(data[i+2 : end+1]) {
+			case "A":
+				keys = append(keys, keyUp)
+			case "B":
+				keys = append(keys, keyDown)
+			case "H", "1~", "7~":
+				keys = append(keys, keyHome)
+			case "F", "4~", "8~":
+				keys = append(keys, keyEnd)
+			case "5~":
+				keys = append(keys, keyPageUp)
+			case "6~":
+				keys = append(keys, keyPageDown)
+			}
+			i = end + 1
+
+		case b == 0x1b:
+			keys = append(keys, keyEscape)
+			i++
+
+		case b == '\r' || b == '\n':
+			keys = append(keys, keyEnter)
+			i++
+
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, keyBackspace)
+			i++
+
+		case b == 0x03:
+			keys = append(keys, keyInterrupt)
+			i++
+
+		case !utf8.FullRune(data[i:]):
+			return keys, i
+
+		default:
+			r, size := utf8.DecodeRune(data[i:])
+			if r != utf8.RuneError && unicode.IsPrint(r) {
+				keys = append(keys, string(r))
+			}
+			i += size
+		}
+	}
+
+	return keys, len(data)
+}
+
+// refresh collects processes and rebuilds the process table. CPU usage is
+// calculated from the CPU time used by each process since the previous
+// refresh. The previous collection is retained if collection fails.
+func (v *interactiveView) refresh() {
+	snapshot, err := v.collector.Snapshot()
+	if err != nil {
+		v.message = "Failed to collect processes: " + err.Error()
+
+		return
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(v.sampledAt)
+
+	cpuTimes := make(map[procstate.ProcessKey]uint64, len(snapshot.Processes))
+	cpu := make(map[procstate.ProcessKey]float64, len(snapshot.Processes))
+	for _, p := range snapshot.Processes {
+		key := p.Key()
+		cpuTimes[key] = p.CPUTime
+
+		prev, ok := v.cpuTimes[key]
+		if ok && p.CPUTime >= prev && elapsed > 0 {
+			used := procstate.ClockTicksToDuration(p.CPUTime - prev)
+			cpu[key] = used.Seconds() / elapsed.Seconds() * 100
+		}
+	}
+
+	v.snapshot = snapshot
+	v.listed = snapshot.Processes.Filter(v.isListed)
+	v.sampledAt = now
+	v.cpuTimes = cpuTimes
+	v.cpu = cpu
+
+	switch errs := snapshot.ProcessErrors(); {
+	case len(errs) > 0:
+		v.message = fmt.Sprintf("Failed to evaluate %d processes", len(errs))
+	default:
+		v.message = ""
+	}
+
+	v.rebuildRows()
+}
+
+// rebuildRows applies the current view, filters and sort order to the most
+// recent collection of processes. The selected process remains selected if
+// still listed.
+func (v *interactiveView) rebuildRows() {
+	var selectedKey procstate.ProcessKey
+	if v.selected < len(v.rows) {
+		selectedKey = v.rows[v.selected].process.Key()
+	}
+
+	isProblem := procstate.ByState(procstate.KnownProblemProcessStates()...)
+	nameFilter := strings.ToLower(v.nameFilter)
+
+	rows := make([]interactiveRow, 0, len(v.listed))
+	for _, p := range v.listed {
+		if !v.showAll && !isProblem(p) {
+			continue
+		}
+
+		if nameFilter != "" && !strings.Contains(strings.ToLower(p.Name), nameFilter) {
+			continue
+		}
+
+		row := interactiveRow{
+			process: p,
+			user:    processUser(p),
+			cpu:     v.cpu[p.Key()],
+		}
+
+		if userFilter := v.userFilter; userFilter != "" {
+			uid, err := p.UID()
+			if row.user != userFilter && (err != nil || strconv.Itoa(uid) != userFilter) {
+				continue
+			}
+		}
+
+		if !v.snapshot.BootTime.IsZero() {
+			row.age = max(v.sampledAt.Sub(p.StartedAt(v.snapshot.BootTime)), 0)
+		}
+
+		rows = append(rows, row)
+	}
+
+	compare := interactiveSortKeys[v.sortIdx].compare
+	slices.SortStableFunc(rows, func(a, b interactiveRow) int {
+		result := compare(a, b)
+		if v.reversed {
+			result = -result
+		}
+
+		return cmp.Or(result, procstate.CompareByPid(a.process, b.process))
+	})
+
+	v.rows = rows
+
+	v.selected = 0
+	for i, row := range rows {
+		if row.process.Key() == selectedKey {
+			v.selected = i
+			break
+		}
+	}
+}
+
+// handleKey updates the view for the given key. The return value indicates
+// whether the user requested to quit.
+func (v *interactiveView) handleKey(key string) bool {
+	if key == keyInterrupt {
+		return true
+	}
+
+	switch {
+	case v.input != nil:
+		v.handleInputKey(key)
+
+		return false
+
+	case v.detail != nil:
+		return v.handleDetailKey(key)
+
+	default:
+		return v.handleListKey(key)
+	}
+}
+
+// handleInputKey updates the value being entered for the given key.
+func (v *interactiveView) handleInputKey(key string) {
+	switch key {
+	case keyEnter:
+		*v.input.target = strings.TrimSpace(v.input.value)
+		v.input = nil
+		v.rebuildRows()
+
+	case keyEscape:
+		v.input = nil
+
+	case keyBackspace:
+		if _, size := utf8.DecodeLastRuneInString(v.input.value); size > 0 {
+			v.input.value = v.input.value[:len(v.input.value)-size]
+		}
+
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			v.input.value += key
+		}
+	}
+}
+
+// handleDetailKey updates the detail view for the given key.
+func (v *interactiveView) handleDetailKey(key string) bool {
+	switch key {
+	case "q":
+		return true
+
+	case keyEscape, keyBackspace, keyEnter, "h":
+		v.detail = nil
+		v.detailOffset = 0
+
+	case keyUp, "k":
+		v.detailOffset = max(v.detailOffset-1, 0)
+
+	case keyDown, "j":
+		v.detailOffset++
+
+	case keyPageUp:
+		v.detailOffset = max(v.detailOffset-v.pageSize, 0)
+
+	case keyPageDown:
+		v.detailOffset += v.pageSize
+
+	case keyHome, "g":
+		v.detailOffset = 0
+
+	case " ":
+		v.refresh()
+	}
+
+	return false
+}
+
+// handleListKey updates the process table for the given key.
+func (v *interactiveView) handleListKey(key string) bool {
+	switch key {
+	case "q":
+		return true
+
+	case keyUp, "k":
+		v.selected--
+
+	case keyDown, "j":
+		v.selected++
+
+	case keyPageUp:
+		v.selected -= v.pageSize
+
+	case keyPageDown:
+		v.selected += v.pageSize
+
+	case keyHome, "g":
+		v.selected = 0
+
+	case keyEnd, "G":
+		v.selected = len(v.rows) - 1
+
+	case keyEnter:
+		if v.selected < len(v.rows) {
+			key := v.rows[v.selected].process.Key()
+			v.detail = &key
+		}
+
+	case "s":
+		v.sortIdx = (v.sortIdx + 1) % len(interactiveSortKeys)
+		v.rebuildRows()
+
+	case "r":
+		v.reversed = !v.reversed
+		v.rebuildRows()
+
+	case "a":
+		v.showAll = !v.showAll
+		v.rebuildRows()
+
+	case "/":
+		v.input = &interactiveInput{prompt: "Filter by name: ", value: v.nameFilter, target: &v.nameFilter}
+
+	case "u":
+		v.input = &interactiveInput{prompt: "Filter by user: ", value: v.userFilter, target: &v.userFilter}
+
+	case keyEscape:
+		v.nameFilter, v.userFilter = "", ""
+		v.rebuildRows()
+
+	case " ":
+		v.refresh()
+	}
+
+	v.selected = max(min(v.selected, len(v.rows)-1), 0)
+
+	return false
+}
+
+// render writes the view to the specified io.Writer for a terminal of the
+// given width and height.
+func (v *interactiveView) render(w io.Writer, width int, height int) {
+	// One line is reserved for the key help.
+	v.pageSize = max(height-interactiveHeaderLines-1, 1)
+
+	var lines []interactiveLine
+	var help string
+
+	switch {
+	case v.detail != nil:
+		lines = v.detailLines(height - 1)
+		help = "esc back  ↑/↓ scroll  space refresh  q quit"
+	default:
+		lines = v.listLines()
+		help = "↑/↓ select  enter details  s sort  r reverse  a all/problems  / name  u user  esc clear  q quit"
+	}
+
+	lines = append(lines, interactiveLine{text: help, highlight: true})
+
+	_, _ = fmt.Fprint(w, ansiCursorHome)
+	for i, line := range lines {
+		// Process names, command lines and proc file content may contain
+		// escape sequences which would otherwise control the terminal.
+		text := textutils.Printable(line.text)
+		if width > 0 {
+			text = truncate(text, width)
+		}
+
+		if line.highlight {
+			pad := max(width-utf8.RuneCountInString(text), 0)
+			text = ansiReverse + text + strings.Repeat(" ", pad) + ansiReset
+		}
+
+		_, _ = fmt.Fprint(w, text, ansiClearLine)
+
+		// A newline after the last line would scroll the terminal.
+		if i < len(lines)-1 {
+			_, _ = fmt.Fprint(w, "\r\n")
+		}
+	}
+	_, _ = fmt.Fprint(w, ansiClearBelow)
+}
+
+// listLines returns the lines of the process table view, including the
+// header with the per-state tally.
+func (v *interactiveView) listLines() []interactiveLine {
+	lines := make([]interactiveLine, 0, interactiveHeaderLines+v.pageSize+1)
+
+	lines = append(lines, interactiveLine{
+		text: fmt.Sprintf(
+			"lsps - %s - %s - Processes: %d, listed: %d",
+			v.snapshot.Hostname,
+			v.sampledAt.Format(time.TimeOnly),
+			len(v.listed),
+			len(v.rows),
+		),
+	})
+
+	lines = append(lines, interactiveLine{
+		text: "States: " + strings.Join(v.listed.SummaryList(), ", "),
+	})
+
+	listing := "problem processes"
+	if v.showAll {
+		listing = "all processes"
+	}
+
+	sortName := interactiveSortKeys[v.sortIdx].name
+	if v.reversed {
+		sortName += " (reversed)"
+	}
+
+	settings := fmt.Sprintf("Listing: %s | Sort: %s", listing, sortName)
+	if v.nameFilter != "" {
+		settings += " | Name: " + v.nameFilter
+	}
+	if v.userFilter != "" {
+		settings += " | User: " + v.userFilter
+	}
+	lines = append(lines, interactiveLine{text: settings})
+
+	switch {
+	case v.input != nil:
+		lines = append(lines, interactiveLine{text: v.input.prompt + v.input.value + "_"})
+	default:
+		lines = append(lines, interactiveLine{text: v.message})
+	}
+
+	lines = append(lines, interactiveLine{
+		text: fmt.Sprintf(
+			"%7s  %-10s  %-16s  %7s  %9s  %9s  %6s  %s",
+			"PID", "USER", "STATE", "THREADS", "SWAP_KB", "AGE", "CPU%", "NAME",
+		),
+		highlight: true,
+	})
+
+	if len(v.rows) == 0 {
+		return append(lines, interactiveLine{text: interactiveNoneText})
+	}
+
+	// Scroll the table so that the selected row is visible.
+	switch {
+	case v.selected < v.offset:
+		v.offset = v.selected
+	case v.selected >= v.offset+v.pageSize:
+		v.offset = v.selected - v.pageSize + 1
+	}
+	v.offset = max(min(v.offset, len(v.rows)-v.pageSize), 0)
+
+	end := min(v.offset+v.pageSize, len(v.rows))
+	for i := v.offset; i < end; i++ {
+		row := v.rows[i]
+		lines = append(lines, interactiveLine{
+			text: fmt.Sprintf(
+				"%7d  %-10s  %-16s  %7d  %9d  %9s  %6.1f  %s",
+				row.process.Pid,
+				truncate(row.user, 10),
+				truncate(row.process.State.String(), 16),
+				row.process.Threads,
+				row.process.VMSwapKB(),
+				formatAge(row.age),
+				row.cpu,
+				row.process.Name,
+			),
+			highlight: i == v.selected,
+		})
+	}
+
+	return lines
+}
+
+// detailLines returns the lines of the detail view for the selected process
+// scrolled to the current offset and limited to the specified height.
+func (v *interactiveView) detailLines(height int) []interactiveLine {
+	var p procstate.Process
+	var found bool
+	for _, candidate := range v.snapshot.Processes {
+		if candidate.Key() == *v.detail {
+			p, found = candidate, true
+			break
+		}
+	}
+
+	if !found {
+		return []interactiveLine{
+			{text: fmt.Sprintf("Process %d is no longer running", v.detail.Pid)},
+		}
+	}
+
+	details := []string{
+		fmt.Sprintf("Process %d (%s)", p.Pid, p.Name),
+		"",
+		fmt.Sprintf("  Name:     %s", p.Name),
+		fmt.Sprintf("  Pid:      %d", p.Pid),
+		fmt.Sprintf("  PPid:     %d (%s)", p.PPid, parentName(p, v.snapshot.Processes)),
+		fmt.Sprintf("  State:    %s", p.State),
+		fmt.Sprintf("  Severity: %s", p.State.Severity),
+		fmt.Sprintf("  User:     %s", processUser(p)),
+		fmt.Sprintf("  Threads:  %d", p.Threads),
+		fmt.Sprintf("  VmRSS:    %d kB", p.VMRSSKB()),
+		fmt.Sprintf("  VmSwap:   %d kB", p.VMSwapKB()),
+		fmt.Sprintf("  CPU time: %s (%.1f%%)", procstate.ClockTicksToDuration(p.CPUTime), v.cpu[p.Key()]),
+	}
+
+	if !v.snapshot.BootTime.IsZero() {
+		started := p.StartedAt(v.snapshot.BootTime)
+		details = append(details, fmt.Sprintf(
+			"  Started:  %s (%s ago)",
+			started.Format(time.DateTime),
+			formatAge(v.sampledAt.Sub(started)),
+		))
+	}
+
+	cmdline, err := p.Cmdline()
+	switch {
+	case err != nil:
+		cmdline = err.Error()
+	case cmdline == "":
+		cmdline = "[" + p.Name + "]"
+	}
+	details = append(details, "  Cmdline:  "+cmdline)
+
+	wchan, err := p.Wchan()
+	if err != nil {
+		wchan = err.Error()
+	}
+	details = append(details, "  Wchan:    "+wchan)
+
+	details = append(details, "", "Status:", "")
+	status, err := os.ReadFile(filepath.Join(p.ProcDir, procstate.ProcStatusFilename))
+	switch {
+	case err != nil:
+		details = append(details, "  "+err.Error())
+	default:
+		for _, line := range strings.Split(strings.TrimSpace(string(status)), "\n") {
+			details = append(details, "  "+strings.ReplaceAll(line, "\t", " "))
+		}
+	}
+
+	details = append(details, "", "Stack:", "")
+	stack, err := p.Stack()
+	switch {
+	case err != nil:
+		details = append(details, "  "+err.Error())
+	case len(stack) == 0:
+		details = append(details, interactiveNoneText)
+	default:
+		for _, line := range stack {
+			details = append(details, "  "+line)
+		}
+	}
+
+	v.detailOffset = max(min(v.detailOffset, len(details)-height), 0)
+	end := min(v.detailOffset+height, len(details))
+
+	lines := make([]interactiveLine, 0, end-v.detailOffset)
+	for _, text := range details[v.detailOffset:end] {
+		lines = append(lines, interactiveLine{text: text})
+	}
+
+	return lines
+}
+
+// parentName returns the name of the parent of the given process from the
+// specified collection or "missing" if not found.
+func parentName(p procstate.Process, processes procstate.Processes) string {
+	parent, err := processes.ParentProcess(p)
+	if err != nil {
+		return "missing"
+	}
+
+	return parent.Name
+}
+
+// formatAge formats the given duration using the two most significant
+// units (e.g., 3d4h, 5h12m, 4m10s).
+func formatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	switch {
+	case d >= day:
+		return fmt.Sprintf("%dd%dh", d/day, d%day/time.Hour)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", d/time.Hour, d%time.Hour/time.Minute)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%ds", d/time.Minute, d%time.Minute/time.Second)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestParseKeys asserts that key names are returned for keyboard input and
+// that an incomplete escape sequence at the end of the input is not
+// consumed.
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data     string
+		wantKeys []string
+		wantUsed int
+	}{
+		"arrow keys": {
+			data:     "\x1b[A\x1b[B\x1bOA\x1bOB",
+			wantKeys: []string{keyUp, keyDown, keyUp, keyDown},
+			wantUsed: 12,
+		},
+		"navigation keys": {
+			data:     "\x1b[5~\x1b[6~\x1b[H\x1b[F\x1b[1~\x1b[4~",
+			wantKeys: []string{keyPageUp, keyPageDown, keyHome, keyEnd, keyHome, keyEnd},
+			wantUsed: 22,
+		},
+		"printable and control keys": {
+			data:     "q/é\r\n\x7f\x08\x03",
+			wantKeys: []string{"q", "/", "é", keyEnter, keyEnter, keyBackspace, keyBackspace, keyInterrupt},
+			wantUsed: 9,
+		},
+		"escape key": {
+			data:     "\x1b",
+			wantKeys: []string{keyEscape},
+			wantUsed: 1,
+		},
+		"escape key followed by printable key": {
+			data:     "\x1bq",
+			wantKeys: []string{keyEscape, "q"},
+			wantUsed: 2,
+		},
+		"split escape sequence": {
+			data:     "q\x1b[",
+			wantKeys: []string{"q"},
+			wantUsed: 1,
+		},
+		"split escape sequence with parameter": {
+			data:     "\x1b[A\x1b[5",
+			wantKeys: []string{keyUp},
+			wantUsed: 3,
+		},
+		"unknown escape sequences": {
+			data:     "\x1b[C\x1b[D\x1b[15~\x1b[1;5Aq",
+			wantKeys: []string{"q"},
+			wantUsed: 18,
+		},
+		"split character": {
+			data:     "q\xc3",
+			wantKeys: []string{"q"},
+			wantUsed: 1,
+		},
+		"unknown bytes": {
+			data:     "\x00\x01\x1f\xff\xfeq\x9bq",
+			wantKeys: []string{"q", "q"},
+			wantUsed: 8,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, used := parseKeys([]byte(tt.data))
+
+			if !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("\nwant keys %q\ngot keys  %q", tt.wantKeys, keys)
+			}
+
+			if used != tt.wantUsed {
+				t.Errorf("want %d bytes consumed, got %d", tt.wantUsed, used)
+			}
+		})
+	}
+}
+
+// TestReadKeysSplitInput asserts that escape sequences and characters split
+// across reads are completed by the following read.
+func TestReadKeysSplitInput(t *testing.T) {
+	t.Parallel()
+
+	// Each reader is consumed by a separate read.
+	r := io.MultiReader(
+		strings.NewReader("\x1b["),
+		strings.NewReader("Aq\x1b[6"),
+		strings.NewReader("~\xc3"),
+		strings.NewReader("\xa9"),
+	)
+
+	var got []string
+	for keys := range readKeys(r) {
+		got = append(got, keys...)
+	}
+
+	want := []string{keyUp, "q", keyPageDown, "é"}
+	if !slices.Equal(got, want) {
+		t.Errorf("\nwant %q\ngot  %q", want, got)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// jsonOutput is the top-level JSON output document. The document embeds
+// the collected Snapshot so that it can be read back using
+// procstate.ReadSnapshot (e.g., by the diff command). See the README for
+// the schema documentation.
+type jsonOutput struct {
+	procstate.Snapshot
+
+	Archive          string            `json:"archive,omitempty"`
+	Summary          procstate.Summary `json:"summary"`
+	ProblemProcesses []jsonProcess     `json:"problem_processes"`
+}
+
+// jsonProcess is a listed process.
+type jsonProcess struct {
+	Name         string      `json:"name"`
+	Pid          int         `json:"pid"`
+	PPid         int         `json:"ppid"`
+	Parent       *jsonParent `json:"parent"`
+	State        string      `json:"state"`
+	Category     string      `json:"category"`
+	Severity     string      `json:"severity"`
+	KernelThread bool        `json:"kernel_thread"`
+	Threads      int         `json:"threads"`
+	VMRSSKB      int         `json:"vmrss_kb"`
+	VMSwapKB     int         `json:"vmswap_kb"`
+	StartTime    uint64      `json:"start_time"`
+}
+
+// jsonParent is the parent of a listed process.
+type jsonParent struct {
+	Name string `json:"name"`
+	Pid  int    `json:"pid"`
+}
+
+// writeJSONOutput collects a snapshot using the given Collector and writes
+// it to the specified io.Writer as a JSON document. The summary and problem
+// processes (along with their parent process) are evaluated from the
+// processes matching the given predicate. Those processes are only listed
+// within the snapshot if all processes are requested. Processes which could
+// not be evaluated are recorded in the snapshot errors.
+func writeJSONOutput(w io.Writer, cfg *config.Config, collector *procstate.Collector, isListed procstate.Predicate) error {
+	snapshot, err := collector.Snapshot()
+	if err != nil {
+		return err
+	}
+	snapshot.ToolVersion = config.Version()
+
+	// Parent processes are resolved using all collected processes.
+	all := snapshot.Processes
+	listed := all.Filter(isListed)
+
+	probProcs := listed.States(procstate.KnownProblemProcessStates())
+
+	snapshot.Processes = nil
+	if cfg.InspectorSettings.ShowAll {
+		snapshot.Processes = listed
+	}
+
+	output := jsonOutput{
+		Snapshot:         snapshot,
+		Archive:          cfg.Archive,
+		Summary:          listed.Summary(procstate.SummaryOptions{}),
+		ProblemProcesses: newJSONProcesses(probProcs, all),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(output); err != nil {
+		return fmt.Errorf("failed to encode output as JSON: %w", err)
+	}
+
+	return nil
+}
+
+// newJSONProcesses converts the given Process values for JSON output. The
+// complete collection of gathered Process values is provided in order to
+// resolve parent processes.
+func newJSONProcesses(processes procstate.Processes, all procstate.Processes) []jsonProcess {
+	// Index processes by ID so that resolving parents does not require
+	// searching the collection for each process.
+	pidIndex := make(map[int]procstate.Process, len(all))
+	for _, p := range all {
+		pidIndex[p.Pid] = p
+	}
+
+	jps := make([]jsonProcess, 0, len(processes))
+	for _, p := range processes {
+		jp := jsonProcess{
+			Name:         p.Name,
+			Pid:          p.Pid,
+			PPid:         p.PPid,
+			State:        p.State.String(),
+			Category:     string(p.State.Category),
+			Severity:     p.State.Severity.String(),
+			KernelThread: procstate.IsKernelThread(p),
+			Threads:      p.Threads,
+			VMRSSKB:      p.VMRSSKB(),
+			VMSwapKB:     p.VMSwapKB(),
+			StartTime:    p.StartTime,
+		}
+
+		if parent, ok := pidIndex[p.PPid]; ok {
+			jp.Parent = &jsonParent{Name: parent.Name, Pid: parent.Pid}
+		}
+
+		jps = append(jps, jp)
+	}
+
+	return jps
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// TestJSONOutputRoundTrip asserts that the JSON output document written
+// with the show-all flag is read back as the snapshot it was written from.
+// Memory usage values not listed for a process (e.g., for kernel threads)
+// remain unset.
+func TestJSONOutputRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+
+	writeTestProcess(t, procRoot, 4321, "rhel6-disk-sleep")
+	writeTestProcess(t, procRoot, 389, "rhel7-kthread")
+
+	collector, err := procstate.NewCollector(procstate.WithProcRoot(procRoot))
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	want, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("failed to collect processes: %v", err)
+	}
+
+	var cfg config.Config
+	cfg.InspectorSettings.ShowAll = true
+
+	var buf bytes.Buffer
+	if err := writeJSONOutput(&buf, &cfg, collector, procstate.And()); err != nil {
+		t.Fatalf("failed to write JSON output: %v", err)
+	}
+
+	snapshot, err := procstate.ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("failed to read JSON output: %v", err)
+	}
+
+	if snapshot.ProcRoot != procRoot {
+		t.Errorf("want proc root %s, got %s", procRoot, snapshot.ProcRoot)
+	}
+
+	if !reflect.DeepEqual(snapshot.Processes, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, snapshot.Processes)
+	}
+
+	kthread := snapshot.Processes.Filter(procstate.ByPid(389))
+	if len(kthread) != 1 || kthread[0].VMRSS != "" || kthread[0].VMSwap != "" {
+		t.Errorf("want kernel thread 389 without memory usage values, got %+v", kthread)
+	}
+}
+
+// TestJSONOutputProblemProcesses asserts that processes are only listed
+// within the JSON output document if all processes are requested while the
+// summary and problem processes are always included.
+func TestJSONOutputProblemProcesses(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+
+	writeTestProcess(t, procRoot, 4321, "rhel6-disk-sleep")
+	writeTestProcess(t, procRoot, 389, "rhel7-kthread")
+
+	collector, err := procstate.NewCollector(procstate.WithProcRoot(procRoot))
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	tests := map[string]struct {
+		showAll       bool
+		wantProcesses string
+	}{
+		"problem processes": {
+			wantProcesses: "null",
+		},
+		"all processes": {
+			showAll:       true,
+			wantProcesses: "array",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg config.Config
+			cfg.InspectorSettings.ShowAll = tt.showAll
+
+			var buf bytes.Buffer
+			if err := writeJSONOutput(&buf, &cfg, collector, procstate.And()); err != nil {
+				t.Fatalf("failed to write JSON output: %v", err)
+			}
+
+			var doc map[string]json.RawMessage
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("failed to decode JSON output: %v", err)
+			}
+
+			processes := "array"
+			if string(doc["processes"]) == "null" {
+				processes = "null"
+			}
+			if processes != tt.wantProcesses {
+				t.Errorf("want processes %s, got %s", tt.wantProcesses, doc["processes"])
+			}
+
+			if _, found := doc["duration"]; found {
+				t.Errorf("want duration listed in seconds only, got duration %s", doc["duration"])
+			}
+
+			var seconds float64
+			if err := json.Unmarshal(doc["duration_seconds"], &seconds); err != nil || seconds <= 0 {
+				t.Errorf("want positive duration_seconds, got %s (error: %v)", doc["duration_seconds"], err)
+			}
+
+			var problems []jsonProcess
+			if err := json.Unmarshal(doc["problem_processes"], &problems); err != nil {
+				t.Fatalf("failed to decode problem processes: %v", err)
+			}
+			if len(problems) != 1 || problems[0].Pid != 4321 {
+				t.Errorf("want problem process 4321, got %+v", problems)
+			}
+
+			var summary procstate.Summary
+			if err := json.Unmarshal(doc["summary"], &summary); err != nil {
+				t.Fatalf("failed to decode summary: %v", err)
+			}
+			if summary.Total != 2 {
+				t.Errorf("want summary of 2 processes, got %d", summary.Total)
+			}
+		})
+	}
+}
@@ -12,17 +12,21 @@ import (
 	"io"
 	"strings"
 
-	"github.com/atc0005/check-process/internal/process"
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
 )
 
 // listProcesses generates a summary of the given Process values and writes it
-// to the specified io.Writer.
-func listProcesses(w io.Writer, processes process.Processes) {
+// to the specified io.Writer. The complete collection of gathered Process
+// values is provided in order to resolve dependencies between processes
+// (e.g., ancestry). Processes are listed in the order given by the specified
+// sort keys.
+func listProcesses(w io.Writer, processes procstate.Processes, all procstate.Processes, sortKeys []config.SortKey) {
 
 	switch {
 	case len(processes) > 0:
-		for _, p := range processes {
-			writeProcessInfoLine(w, p, processes, true)
+		for _, p := range sortProcesses(processes, newPidIndex(all), sortKeys) {
+			writeProcessInfoLine(w, p, all, true)
 		}
 
 		_, _ = fmt.Fprintf(w, "\nSummary:\n\n")
@@ -37,55 +41,88 @@ func listProcesses(w io.Writer, processes process.Processes) {
 
 }
 
-// listOtherProcesses generates a summary of the given Process values from the
-// complete collection that are not present in the specified evaluated set.
-// The summary is written to the specified io.Writer.
-func listOtherProcesses(w io.Writer, evaluated process.Processes, all process.Processes, includeDetails bool) {
-
-	remaining := all.Exclude(evaluated...)
+// listOtherProcesses generates a summary of the given listed Process values
+// that are not present in the specified evaluated set. The summary is
+// written to the specified io.Writer. The complete collection of gathered
+// Process values is provided in order to resolve parent processes. If
+// requested, details are listed for each process grouped by state, most
+// severe state first, with processes listed in the order given by the
+// specified sort keys.
+func listOtherProcesses(
+	w io.Writer,
+	evaluated procstate.Processes,
+	listed procstate.Processes,
+	all procstate.Processes,
+	includeDetails bool,
+	sortKeys []config.SortKey,
+) {
+
+	remaining := listed.Exclude(evaluated...)
 
 	if len(remaining) == 0 {
 		return
 	}
 
-	_, _ = fmt.Fprintf(w, "\nSUMMARY:\n\n")
-	for _, item := range remaining.SummaryList() {
-		_, _ = fmt.Fprintf(w, "  - %s\n", item)
-	}
-	_, _ = fmt.Fprintln(w)
+	writeOtherProcessesSummary(w, remaining.Summary(procstate.SummaryOptions{}))
 
 	if includeDetails {
-		fmt.Printf("\n%s\n\n", strings.Repeat("-", 50))
-
-		_, _ = fmt.Fprintf(w, "\nDETAILS:\n")
+		_, _ = fmt.Fprintf(w, "\n%s\n\n", strings.Repeat("-", 50))
 
-		// for _, p := range remaining {
-		// 	writeProcessInfoLine(w, p, remaining)
-		// }
+		groups := remaining.GroupByState()
+		pidIndex := newPidIndex(all)
 
-		// Create index of process state type to process collection of that state
-		// type.
-		stateIndex := make(map[string]process.Processes)
-		for _, p := range remaining {
-			stateIndex[p.State] = append(stateIndex[p.State], p)
-		}
+		_, _ = fmt.Fprintf(
+			w,
+			"\nDETAILS (%d processes in %d states):\n",
+			len(remaining),
+			len(groups),
+		)
 
 		// Write out the state as a header, emit the processes for that state.
-		for state, ps := range stateIndex {
-			_, _ = fmt.Fprintf(w, "\n%s\n", state)
-			for _, p := range ps {
-				writeProcessInfoLine(w, p, remaining, false)
+		for _, group := range groups {
+			_, _ = fmt.Fprintf(
+				w,
+				"\n== %s [%d of %d processes] ==\n\n",
+				group.State,
+				len(group.Processes),
+				len(remaining),
+			)
+
+			for _, p := range sortProcesses(group.Processes, pidIndex, sortKeys) {
+				writeProcessInfoLine(w, p, all, false)
 			}
 		}
 	}
 
 }
 
+// textSortKeys returns the sort keys used to order processes listed with
+// the text output format. Processes are ordered by process ID unless
+// requested otherwise; the process ID is always used as the final sort key
+// so that the order is stable between runs.
+func textSortKeys(cfg *config.Config) []config.SortKey {
+	return append(cfg.SortKeys(), config.SortKey{Column: config.ColumnPid})
+}
+
+// writeOtherProcessesSummary writes the given summary of processes not
+// otherwise listed to the specified io.Writer.
+func writeOtherProcessesSummary(w io.Writer, summary procstate.Summary) {
+	if summary.Total == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\nSUMMARY:\n\n")
+	for _, item := range summary.StateList() {
+		_, _ = fmt.Fprintf(w, "  - %s\n", item)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
 // writeProcessInfoLine generates a summary of the given Process and writes it
 // to the specified io.Writer in a one-line format. The collection of gathered
 // Process values is provided in order to resolve dependencies between
 // processes (e.g., ancestry).
-func writeProcessInfoLine(w io.Writer, p process.Process, ps process.Processes, includeStateField bool) {
+func writeProcessInfoLine(w io.Writer, p procstate.Process, ps procstate.Processes, includeStateField bool) {
 	parentProcess, err := p.ParentProcess(ps)
 	ppName, ppID := parentProcess.Name, parentProcess.Pid
 	if err != nil {
@@ -0,0 +1,123 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// TestListOtherProcessesOrder asserts that the details of processes not
+// otherwise listed are grouped by state, most severe state first and then
+// in the order of the known process state table, and that processes are
+// listed in the order given by the sort keys within each state.
+func TestListOtherProcessesOrder(t *testing.T) {
+	t.Parallel()
+
+	unknown := procstate.ProcessState{Code: 'Q', Description: "queued", Category: procstate.StateCategoryUnknown}
+
+	all := procstate.Processes{
+		{Name: "systemd", Pid: 1, PPid: 0, Threads: 1, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "sshd", Pid: 900, PPid: 1, Threads: 1, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "odd", Pid: 950, PPid: 1, Threads: 1, State: unknown},
+		{Name: "java", Pid: 4321, PPid: 1, Threads: 87, State: procstate.KernelAnyProcessStateDiskSleep},
+		{Name: "defunct", Pid: 31337, PPid: 900, Threads: 1, State: procstate.KernelAnyProcessStateZombie},
+		{Name: "top", Pid: 700, PPid: 900, Threads: 1, State: procstate.KernelAnyProcessStateRunning},
+		{Name: "rsync", Pid: 50, PPid: 900, Threads: 3, State: procstate.KernelAnyProcessStateDiskSleep},
+		{Name: "bash", Pid: 800, PPid: 900, Threads: 2, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "kworker/0:1", Pid: 61012, PPid: 2, Threads: 1, State: procstate.KernelCurrentProcessStateIdle},
+	}
+
+	// Problem processes listed separately are not listed again.
+	evaluated := all.States(procstate.KnownProblemProcessStates()).Filter(procstate.ByPid(4321))
+
+	type group struct {
+		header string
+		pids   []int
+	}
+
+	tests := map[string]struct {
+		sort string
+		want []group
+	}{
+		"default sort": {
+			want: []group{
+				{header: "== D (disk sleep) [1 of 8 processes] ==", pids: []int{50}},
+				{header: "== Z (zombie) [1 of 8 processes] ==", pids: []int{31337}},
+				{header: "== R (running) [1 of 8 processes] ==", pids: []int{700}},
+				{header: "== S (sleeping) [3 of 8 processes] ==", pids: []int{1, 800, 900}},
+				{header: "== I (idle) [1 of 8 processes] ==", pids: []int{61012}},
+				{header: "== Q (queued) [1 of 8 processes] ==", pids: []int{950}},
+			},
+		},
+		"sort by threads descending": {
+			sort: "-" + config.ColumnThreads,
+			want: []group{
+				{header: "== D (disk sleep) [1 of 8 processes] ==", pids: []int{50}},
+				{header: "== Z (zombie) [1 of 8 processes] ==", pids: []int{31337}},
+				{header: "== R (running) [1 of 8 processes] ==", pids: []int{700}},
+				{header: "== S (sleeping) [3 of 8 processes] ==", pids: []int{800, 1, 900}},
+				{header: "== I (idle) [1 of 8 processes] ==", pids: []int{61012}},
+				{header: "== Q (queued) [1 of 8 processes] ==", pids: []int{950}},
+			},
+		},
+		"sort by name": {
+			sort: config.ColumnName,
+			want: []group{
+				{header: "== D (disk sleep) [1 of 8 processes] ==", pids: []int{50}},
+				{header: "== Z (zombie) [1 of 8 processes] ==", pids: []int{31337}},
+				{header: "== R (running) [1 of 8 processes] ==", pids: []int{700}},
+				{header: "== S (sleeping) [3 of 8 processes] ==", pids: []int{800, 900, 1}},
+				{header: "== I (idle) [1 of 8 processes] ==", pids: []int{61012}},
+				{header: "== Q (queued) [1 of 8 processes] ==", pids: []int{950}},
+			},
+		},
+	}
+
+	pidPattern := regexp.MustCompile(`, Pid: (\d+),`)
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg config.Config
+			cfg.InspectorSettings.Sort = tt.sort
+
+			var buf bytes.Buffer
+			listOtherProcesses(&buf, evaluated, all, all, true, textSortKeys(&cfg))
+
+			var got []group
+			for _, line := range strings.Split(buf.String(), "\n") {
+				switch {
+				case strings.HasPrefix(line, "== "):
+					got = append(got, group{header: line})
+
+				case strings.HasPrefix(line, "  - Name:"):
+					match := pidPattern.FindStringSubmatch(line)
+					if match == nil || len(got) == 0 {
+						t.Fatalf("unexpected process line %q", line)
+					}
+
+					pid, _ := strconv.Atoi(match[1])
+					got[len(got)-1].pids = append(got[len(got)-1].pids, pid)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\nwant %+v\ngot  %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -15,7 +15,7 @@ import (
 	"os"
 
 	"github.com/atc0005/check-process/internal/config"
-	"github.com/atc0005/check-process/internal/process"
+	"github.com/atc0005/check-process/pkg/procstate"
 	"github.com/rs/zerolog"
 )
 
@@ -48,43 +48,266 @@ func main() {
 
 	logger := cfg.Log.With().Logger()
 
-	logger.Debug().
-		Str("base_path", process.ProcRootDir).
-		Msg("Collecting process paths")
-	procDirs, err := process.GetProcDirs(process.ProcRootDir)
+	if cfg.InspectorSettings.Command == config.CommandCapture {
+		if err := runCapture(cfg, logger); err != nil {
+			logger.Error().Err(err).Msg("Failed to capture proc filesystem")
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+	}
+
+	if cfg.InspectorSettings.Command == config.CommandDiff {
+		args := cfg.InspectorSettings.CommandArgs
+		if err := runDiff(os.Stdout, cfg, logger, args[0], args[1]); err != nil {
+			logger.Error().Err(err).Msg("Failed to compare processes")
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+	}
+
+	var filters []procstate.Predicate
+	opts := make([]procstate.Option, 0, 2)
+
+	cleanup := func() {}
+
+	switch {
+	case cfg.Archive != "":
+		logger.Debug().
+			Str("archive", cfg.Archive).
+			Msg("Extracting capture archive")
+
+		procRoot, cleanupArchive, err := procstate.ExtractArchiveFile(cfg.Archive)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to extract capture archive")
+			os.Exit(config.ExitCodeCatchall)
+		}
+		cleanup = cleanupArchive
+		defer cleanup()
+
+		opts = append(opts, procstate.WithProcRoot(procRoot))
+
+	default:
+		logger.Debug().
+			Str("my_name", os.Args[0]).
+			Int("my_process_id", os.Getpid()).
+			Msg("Excluding process of current tool")
+
+		filters = append(filters, procstate.Not(procstate.ByPid(os.Getpid())))
+	}
+
+	if len(cfg.InspectorSettings.Pids) > 0 {
+		// Details are gathered from a collection which is not subject to
+		// the kernel thread setting so that the requested processes and
+		// their ancestors are always found. All status file properties
+		// are included in the details of requested processes.
+		opts = append(opts, procstate.WithFilter(filters...), procstate.WithAllProperties())
+
+		collector, err := procstate.NewCollector(opts...)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to initialize process collector")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		logger.Debug().
+			Str("base_path", collector.ProcRoot()).
+			Ints("pids", cfg.InspectorSettings.Pids).
+			Msg("Collecting processes")
+
+		if err := writeProcessDetails(os.Stdout, cfg, collector); err != nil {
+			logger.Error().Err(err).Msg("Failed to inspect processes")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+	}
+
+	opts = append(opts, procstate.WithFilter(filters...))
+
+	// Kernel threads and processes not matching the user-specified filters
+	// are collected but not listed so that the parent and ancestors of
+	// listed processes can be resolved.
+	var listFilters []procstate.Predicate
+
+	if cfg.KernelThreads == config.KernelThreadsExclude {
+		logger.Debug().Msg("Excluding kernel threads")
+		listFilters = append(listFilters, procstate.Not(procstate.IsKernelThread))
+	}
+
+	if userFilters := cfg.Filters(); len(userFilters) > 0 {
+		logger.Debug().
+			Int("filters", len(userFilters)).
+			Msg("Applying user-specified filters")
+		listFilters = append(listFilters, userFilters...)
+	}
+
+	isListed := procstate.And(listFilters...)
+
+	if cfg.InspectorSettings.ShowAll && cfg.InspectorSettings.Output == config.OutputJSON {
+		// All status file properties are included in the snapshot
+		// processes of the JSON output.
+		opts = append(opts, procstate.WithAllProperties())
+	}
+
+	collector, err := procstate.NewCollector(opts...)
 	if err != nil {
-		logger.Error().Err(err).Msg("Failed to evaluate process directories")
+		logger.Error().Err(err).Msg("Failed to initialize process collector")
+		cleanup()
 		os.Exit(config.ExitCodeCatchall)
 	}
 
 	logger.Debug().
-		Str("base_path", process.ProcRootDir).
-		Int("process_paths", len(procDirs)).
-		Msg("Successfully collected process paths")
+		Str("base_path", collector.ProcRoot()).
+		Msg("Collecting processes")
 
-	processes, err := process.FromProcDirs(procDirs)
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to obtain list of process values")
-		os.Exit(config.ExitCodeCatchall)
+	switch {
+	case cfg.InspectorSettings.ExplainStates:
+		writeStateExplanations(os.Stdout, cfg, collector.ProcRoot())
+
+		return
+
+	case cfg.InspectorSettings.Interactive:
+		if err := runInteractive(logger, collector, isListed, cfg.InspectorSettings.ShowAll); err != nil {
+			logger.Error().Err(err).Msg("Failed to run interactive mode")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+
+	case cfg.InspectorSettings.Watch > 0:
+		if err := runWatch(os.Stdout, logger, collector, isListed, cfg.InspectorSettings.Watch); err != nil {
+			logger.Error().Err(err).Msg("Failed to sample processes")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+
+	case cfg.InspectorSettings.Tree:
+		if err := writeTreeOutput(os.Stdout, cfg, collector, isListed); err != nil {
+			logger.Error().Err(err).Msg("Failed to generate process tree")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+
+	case cfg.InspectorSettings.Output == config.OutputJSON:
+		if err := writeJSONOutput(os.Stdout, cfg, collector, isListed); err != nil {
+			logger.Error().Err(err).Msg("Failed to generate JSON output")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+
+	case cfg.InspectorSettings.Output == config.OutputTable,
+		cfg.InspectorSettings.Output == config.OutputCSV:
+		if err := writeTabularOutput(os.Stdout, cfg, collector, isListed); err != nil {
+			logger.Error().Err(err).Msg("Failed to generate tabular output")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		return
+	}
+
+	var probProcs procstate.Processes
+	var processes procstate.Processes
+	var listed procstate.Processes
+	var remaining procstate.Summary
+
+	switch {
+	case cfg.InspectorSettings.ShowAll:
+		// Details for all processes are listed, so we retain the full
+		// collection.
+		processes, err = collector.Collect()
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to obtain list of process values")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
+
+		listed = processes.Filter(isListed)
+		probProcs = listed.States(procstate.KnownProblemProcessStates())
+
+	default:
+		// Only problem processes are listed, so we tally other processes
+		// as they are read instead of retaining them.
+		probProcs, processes, remaining, err = collectProblemProcesses(collector, isListed)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to obtain list of process values")
+			cleanup()
+			os.Exit(config.ExitCodeCatchall)
+		}
 	}
 
 	logger.Debug().
 		Int("processes", len(processes)).
+		Int("problem_processes", len(probProcs)).
 		Msg("Collected info on processes")
 
-	logger.Debug().
-		Str("my_name", os.Args[0]).
-		Int("my_process_id", os.Getpid()).
-		Msg("Excluding process of current tool")
-	processes = processes.ExcludeMyPID()
+	sortKeys := textSortKeys(cfg)
 
-	logger.Debug().
-		Int("processes", len(processes)).
-		Msg("Excluded process of current tool")
+	switch {
+	case cfg.KernelThreads == config.KernelThreadsSeparate:
+		fmt.Println("Problematic userland processes:")
+		listProcesses(os.Stdout, probProcs.Userland(), processes, sortKeys)
+
+		fmt.Println("\nProblematic kernel threads:")
+		listProcesses(os.Stdout, probProcs.KernelThreads(), processes, sortKeys)
 
-	fmt.Println("Problematic processes:")
+	default:
+		fmt.Println("Problematic processes:")
+		listProcesses(os.Stdout, probProcs, processes, sortKeys)
+	}
+
+	switch {
+	case cfg.InspectorSettings.ShowAll:
+		listOtherProcesses(os.Stdout, probProcs, listed, processes, true, sortKeys)
+	default:
+		writeOtherProcessesSummary(os.Stdout, remaining)
+	}
+}
+
+// collectProblemProcesses evaluates processes one at a time using the given
+// Collector. Listed problem processes are retained along with a minimal
+// index of all processes (name and process ID values only) used to resolve
+// parent processes. A summary of all other listed processes is returned.
+func collectProblemProcesses(
+	collector *procstate.Collector,
+	isListed procstate.Predicate,
+) (procstate.Processes, procstate.Processes, procstate.Summary, error) {
+	probProcs := make(procstate.Processes, 0)
+	index := make(procstate.Processes, 0)
+
+	var remaining procstate.SummaryBuilder
+
+	isProblem := procstate.ByState(procstate.KnownProblemProcessStates()...)
+
+	for p, err := range collector.All() {
+		if err != nil {
+			return nil, nil, procstate.Summary{}, err
+		}
+
+		index = append(index, procstate.Process{
+			Name: p.Name,
+			Pid:  p.Pid,
+			PPid: p.PPid,
+		})
+
+		switch {
+		case !isListed(p):
+		case isProblem(p):
+			probProcs = append(probProcs, p)
+		default:
+			remaining.Add(p)
+		}
+	}
 
-	probProcs := processes.States(process.KnownProblemProcessStates())
-	listProcesses(os.Stdout, probProcs)
-	listOtherProcesses(os.Stdout, probProcs, processes, cfg.InspectorSettings.ShowAll)
+	return probProcs, index, remaining.Summary(procstate.SummaryOptions{}), nil
 }
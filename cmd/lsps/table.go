@@ -0,0 +1,362 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/internal/textutils"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// truncationSuffix is appended to values truncated to the requested width.
+const truncationSuffix string = "…"
+
+// tableColumn describes a column of the table and csv output formats.
+type tableColumn struct {
+	// header is the column header.
+	header string
+
+	// numeric indicates whether column values are compared as numbers when
+	// sorting.
+	numeric bool
+
+	// compare is an optional function used to compare processes when
+	// sorting by the column. Column values are compared if not set.
+	compare procstate.CompareFunc
+
+	// value returns the column value for a process. An index of all
+	// processes by process ID is provided in order to resolve parent
+	// processes.
+	value func(p procstate.Process, pidIndex map[int]procstate.Process) string
+}
+
+// tableColumns is the index of supported columns for the table and csv
+// output formats.
+var tableColumns = map[string]tableColumn{
+	config.ColumnPid: {
+		header:  "PID",
+		numeric: true,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.Itoa(p.Pid)
+		},
+	},
+	config.ColumnPPid: {
+		header:  "PPID",
+		numeric: true,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.Itoa(p.PPid)
+		},
+	},
+	config.ColumnName: {
+		header: "NAME",
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return p.Name
+		},
+	},
+	config.ColumnParent: {
+		header: "PARENT",
+		value: func(p procstate.Process, pidIndex map[int]procstate.Process) string {
+			if parent, ok := pidIndex[p.PPid]; ok {
+				return parent.Name
+			}
+
+			return "missing"
+		},
+	},
+	config.ColumnState: {
+		header:  "STATE",
+		compare: procstate.CompareByState,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return p.State.String()
+		},
+	},
+	config.ColumnCategory: {
+		header: "CATEGORY",
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return string(p.State.Category)
+		},
+	},
+	config.ColumnSeverity: {
+		header: "SEVERITY",
+		// Most severe last so that a descending sort lists the most severe
+		// processes first.
+		compare: procstate.Reverse(procstate.CompareBySeverity),
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return p.State.Severity.String()
+		},
+	},
+	config.ColumnThreads: {
+		header:  "THREADS",
+		numeric: true,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.Itoa(p.Threads)
+		},
+	},
+	config.ColumnVMRSS: {
+		header:  "VMRSS_KB",
+		numeric: true,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.Itoa(p.VMRSSKB())
+		},
+	},
+	config.ColumnVMSwap: {
+		header:  "VMSWAP_KB",
+		numeric: true,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.Itoa(p.VMSwapKB())
+		},
+	},
+	config.ColumnUser: {
+		header: "USER",
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return processUser(p)
+		},
+	},
+	config.ColumnKThread: {
+		header: "KTHREAD",
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.FormatBool(p.IsKernelThread())
+		},
+	},
+	config.ColumnStartTime: {
+		header:  "STARTTIME",
+		numeric: true,
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			return strconv.FormatUint(p.StartTime, 10)
+		},
+	},
+	config.ColumnCmdline: {
+		header: "CMDLINE",
+		value: func(p procstate.Process, _ map[int]procstate.Process) string {
+			cmdline, err := p.Cmdline()
+			if err != nil || cmdline == "" {
+				// Kernel threads (and processes which have exited) do not
+				// provide a command line.
+				return "[" + p.Name + "]"
+			}
+
+			return cmdline
+		},
+	},
+}
+
+// tableRows generates the rows for the given Process values using the
+// specified columns, sorting and truncation settings. The complete
+// collection of gathered Process values is provided in order to resolve
+// parent processes.
+func tableRows(processes procstate.Processes, all procstate.Processes, columns []string, cfg *config.Config) [][]string {
+	pidIndex := newPidIndex(all)
+
+	if sortKeys := cfg.SortKeys(); len(sortKeys) > 0 {
+		processes = sortProcesses(processes, pidIndex, sortKeys)
+	}
+
+	width := cfg.InspectorSettings.Truncate
+
+	rows := make([][]string, 0, len(processes))
+	for _, p := range processes {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			value := tableColumns[column].value(p, pidIndex)
+			if width > 0 {
+				value = truncate(value, width)
+			}
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// newPidIndex returns an index of the given Process values by process ID.
+func newPidIndex(processes procstate.Processes) map[int]procstate.Process {
+	pidIndex := make(map[int]procstate.Process, len(processes))
+	for _, p := range processes {
+		pidIndex[p.Pid] = p
+	}
+
+	return pidIndex
+}
+
+// sortProcesses returns a copy of the given Process values sorted by the
+// specified columns in order of precedence. Processes with equal column
+// values retain their original order.
+func sortProcesses(
+	processes procstate.Processes,
+	pidIndex map[int]procstate.Process,
+	sortKeys []config.SortKey,
+) procstate.Processes {
+	type keyedProcess struct {
+		keys    []string
+		process procstate.Process
+	}
+
+	// Column values are generated once per process as some values are
+	// read from the proc filesystem on demand.
+	keyed := make([]keyedProcess, 0, len(processes))
+	for _, p := range processes {
+		keys := make([]string, 0, len(sortKeys))
+		for _, sortKey := range sortKeys {
+			keys = append(keys, tableColumns[sortKey.Column].value(p, pidIndex))
+		}
+		keyed = append(keyed, keyedProcess{keys: keys, process: p})
+	}
+
+	compare := func(a, b keyedProcess) int {
+		for i, sortKey := range sortKeys {
+			column := tableColumns[sortKey.Column]
+
+			var result int
+			switch {
+			case column.compare != nil:
+				result = column.compare(a.process, b.process)
+			case column.numeric:
+				aNum, _ := strconv.ParseUint(a.keys[i], 10, 64)
+				bNum, _ := strconv.ParseUint(b.keys[i], 10, 64)
+				result = cmp.Compare(aNum, bNum)
+			default:
+				result = strings.Compare(a.keys[i], b.keys[i])
+			}
+
+			if sortKey.Descending {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	}
+
+	slices.SortStableFunc(keyed, compare)
+
+	sorted := make(procstate.Processes, 0, len(keyed))
+	for _, kp := range keyed {
+		sorted = append(sorted, kp.process)
+	}
+
+	return sorted
+}
+
+// processUser returns the name of the user associated with the given
+// process, falling back to the user ID if the name cannot be resolved.
+func processUser(p procstate.Process) string {
+	if username, err := p.Username(); err == nil {
+		return username
+	}
+
+	if uid, err := p.UID(); err == nil {
+		return strconv.Itoa(uid)
+	}
+
+	return "unknown"
+}
+
+// truncate shortens the given value to the specified width (in characters)
+// if longer, replacing the last character with truncationSuffix.
+func truncate(value string, width int) string {
+	if utf8.RuneCountInString(value) <= width {
+		return value
+	}
+
+	runes := []rune(value)
+
+	return string(runes[:width-1]) + truncationSuffix
+}
+
+// tableHeaders returns the headers for the specified columns.
+func tableHeaders(columns []string) []string {
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, tableColumns[column].header)
+	}
+
+	return headers
+}
+
+// writeTable writes the given Process values to the specified io.Writer as
+// a table of aligned columns. Non-printable characters within values are
+// escaped.
+func writeTable(w io.Writer, processes procstate.Processes, all procstate.Processes, cfg *config.Config) error {
+	columns := cfg.OutputColumns()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, strings.Join(tableHeaders(columns), "\t"))
+	for _, row := range tableRows(processes, all, columns, cfg) {
+		// Values such as the command line are controlled by the process
+		// owner. Control characters are escaped so that they cannot
+		// inject terminal escape sequences or break the column alignment.
+		for i := range row {
+			row[i] = textutils.Printable(row[i])
+		}
+
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write table output: %w", err)
+	}
+
+	return nil
+}
+
+// writeCSV writes the given Process values to the specified io.Writer as
+// comma-separated values with a header row. Values are written as-is (with
+// quoting as needed) for processing by other tools.
+func writeCSV(w io.Writer, processes procstate.Processes, all procstate.Processes, cfg *config.Config) error {
+	columns := cfg.OutputColumns()
+
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(tableHeaders(columns)); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	if err := cw.WriteAll(tableRows(processes, all, columns, cfg)); err != nil {
+		return fmt.Errorf("failed to write CSV output: %w", err)
+	}
+
+	return nil
+}
+
+// writeTabularOutput collects processes using the given Collector and
+// writes the processes matching the given predicate to the specified
+// io.Writer using the table or csv output format. Problem processes are
+// listed unless all processes are requested.
+func writeTabularOutput(w io.Writer, cfg *config.Config, collector *procstate.Collector, isListed procstate.Predicate) error {
+	processes, err := collector.Collect()
+	if err != nil {
+		return err
+	}
+
+	listed := processes.Filter(isListed)
+	if !cfg.InspectorSettings.ShowAll {
+		listed = listed.States(procstate.KnownProblemProcessStates())
+	}
+
+	switch cfg.InspectorSettings.Output {
+	case config.OutputCSV:
+		return writeCSV(w, listed, processes, cfg)
+	default:
+		return writeTable(w, listed, processes, cfg)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// TestWriteTableEscapesCmdline asserts that terminal escape sequences
+// within a command line are escaped when writing a table.
+func TestWriteTableEscapesCmdline(t *testing.T) {
+	t.Parallel()
+
+	procDir := t.TempDir()
+	cmdline := "sleep\x00\x1b]0;pwned\x07\x1b[2J\x00\t300\x00"
+	if err := os.WriteFile(filepath.Join(procDir, procstate.ProcCmdlineFilename), []byte(cmdline), 0o600); err != nil {
+		t.Fatalf("failed to create cmdline file: %v", err)
+	}
+
+	processes := procstate.Processes{
+		{Name: "sleep", Pid: 100, PPid: 1, State: procstate.KernelAnyProcessStateSleeping, ProcDir: procDir},
+	}
+
+	var cfg config.Config
+	cfg.InspectorSettings.Columns = []string{config.ColumnPid, config.ColumnCmdline}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, processes, processes, &cfg); err != nil {
+		t.Fatalf("failed to write table: %v", err)
+	}
+
+	want := "PID  CMDLINE\n100  sleep \\x1b]0;pwned\\a\\x1b[2J \\t300\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\nwant %q\ngot  %q", want, got)
+	}
+
+	if strings.ContainsRune(buf.String(), '\x1b') {
+		t.Errorf("escape character written to table")
+	}
+}
+
+// TestTruncate asserts that values are truncated to the specified width in
+// characters, including values with multi-byte characters.
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		width int
+		want  string
+	}{
+		"shorter than width":           {value: "java", width: 10, want: "java"},
+		"equal to width":               {value: "java", width: 4, want: "java"},
+		"longer than width":            {value: "kworker/u8:2", width: 8, want: "kworker…"},
+		"multi-byte equal to width":    {value: "résumé", width: 6, want: "résumé"},
+		"multi-byte longer than width": {value: "Web Content (日本語)", width: 15, want: "Web Content (日…"},
+		"multi-byte at truncation":     {value: "naïveté", width: 4, want: "naï…"},
+		"width of one":                 {value: "java", width: 1, want: "…"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := truncate(tt.value, tt.width); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSortProcesses asserts that processes are sorted by each column in
+// order of precedence and that processes with equal values retain their
+// original order.
+func TestSortProcesses(t *testing.T) {
+	t.Parallel()
+
+	processes := procstate.Processes{
+		{Name: "java", Pid: 4321, PPid: 1, Threads: 87, State: procstate.KernelAnyProcessStateDiskSleep},
+		{Name: "bash", Pid: 900, PPid: 1, Threads: 1, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "defunct", Pid: 31337, PPid: 900, Threads: 1, State: procstate.KernelAnyProcessStateZombie},
+		{Name: "sshd", Pid: 1000, PPid: 1, Threads: 1, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "rsync", Pid: 50, PPid: 900, Threads: 3, State: procstate.KernelAnyProcessStateDiskSleep},
+	}
+
+	tests := map[string]struct {
+		sortKeys []config.SortKey
+		want     []int
+	}{
+		"numeric column": {
+			sortKeys: []config.SortKey{{Column: config.ColumnPid}},
+			want:     []int{50, 900, 1000, 4321, 31337},
+		},
+		"numeric column descending": {
+			sortKeys: []config.SortKey{{Column: config.ColumnPid, Descending: true}},
+			want:     []int{31337, 4321, 1000, 900, 50},
+		},
+		"equal values retain order": {
+			sortKeys: []config.SortKey{{Column: config.ColumnThreads}},
+			want:     []int{900, 31337, 1000, 50, 4321},
+		},
+		"equal values retain order descending": {
+			sortKeys: []config.SortKey{{Column: config.ColumnPPid, Descending: true}},
+			want:     []int{31337, 50, 4321, 900, 1000},
+		},
+		"multiple columns": {
+			sortKeys: []config.SortKey{{Column: config.ColumnPPid}, {Column: config.ColumnName}},
+			want:     []int{900, 4321, 1000, 31337, 50},
+		},
+		"severity descending": {
+			sortKeys: []config.SortKey{{Column: config.ColumnSeverity, Descending: true}},
+			want:     []int{4321, 50, 31337, 900, 1000},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			original := slices.Clone(processes)
+
+			sorted := sortProcesses(processes, newPidIndex(processes), tt.sortKeys)
+
+			got := make([]int, 0, len(sorted))
+			for _, p := range sorted {
+				got = append(got, p.Pid)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant %v\ngot  %v", tt.want, got)
+			}
+
+			if !reflect.DeepEqual(processes, original) {
+				t.Errorf("given processes modified")
+			}
+		})
+	}
+}
+
+// TestWriteTableAndCSV asserts that processes are written as aligned
+// columns or comma-separated values using the configured columns, sorting
+// and truncation settings and that values are quoted as needed in CSV
+// output.
+func TestWriteTableAndCSV(t *testing.T) {
+	t.Parallel()
+
+	procDir := t.TempDir()
+	cmdline := "/usr/bin/java\x00-Dnames=a,b\x00-Dgreeting=\"hi\"\x00"
+	if err := os.WriteFile(filepath.Join(procDir, procstate.ProcCmdlineFilename), []byte(cmdline), 0o600); err != nil {
+		t.Fatalf("failed to create cmdline file: %v", err)
+	}
+
+	processes := procstate.Processes{
+		{Name: "systemd", Pid: 1, PPid: 0, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "java", Pid: 4321, PPid: 1, Threads: 87, State: procstate.KernelAnyProcessStateDiskSleep, ProcDir: procDir},
+		{Name: "line\nbreak", Pid: 4400, PPid: 4321, Threads: 1, State: procstate.KernelAnyProcessStateZombie},
+	}
+	listed := processes[1:]
+
+	var cfg config.Config
+	cfg.InspectorSettings.Columns = []string{config.ColumnPid, config.ColumnName, config.ColumnParent, config.ColumnCmdline}
+	cfg.InspectorSettings.Sort = "-" + config.ColumnPid
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := writeTable(&buf, listed, processes, &cfg); err != nil {
+			t.Fatalf("failed to write table: %v", err)
+		}
+
+		want := strings.Join([]string{
+			"PID   NAME         PARENT   CMDLINE",
+			"4400  line\\nbreak  java     [line\\nbreak]",
+			`4321  java         systemd  /usr/bin/java -Dnames=a,b -Dgreeting="hi"`,
+			"",
+		}, "\n")
+
+		if got := buf.String(); got != want {
+			t.Errorf("\nwant %q\ngot  %q", want, got)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := writeCSV(&buf, listed, processes, &cfg); err != nil {
+			t.Fatalf("failed to write CSV: %v", err)
+		}
+
+		want := strings.Join([]string{
+			"PID,NAME,PARENT,CMDLINE",
+			"4400,\"line\nbreak\",java,\"[line\nbreak]\"",
+			`4321,java,systemd,"/usr/bin/java -Dnames=a,b -Dgreeting=""hi"""`,
+			"",
+		}, "\n")
+
+		if got := buf.String(); got != want {
+			t.Errorf("\nwant %q\ngot  %q", want, got)
+		}
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("failed to read CSV output: %v", err)
+		}
+
+		wantRecords := [][]string{
+			{"PID", "NAME", "PARENT", "CMDLINE"},
+			{"4400", "line\nbreak", "java", "[line\nbreak]"},
+			{"4321", "java", "systemd", `/usr/bin/java -Dnames=a,b -Dgreeting="hi"`},
+		}
+		if !reflect.DeepEqual(records, wantRecords) {
+			t.Errorf("\nwant %q\ngot  %q", wantRecords, records)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := cfg
+		cfg.InspectorSettings.Truncate = 6
+
+		var buf bytes.Buffer
+		if err := writeCSV(&buf, listed, processes, &cfg); err != nil {
+			t.Fatalf("failed to write CSV: %v", err)
+		}
+
+		want := "PID,NAME,PARENT,CMDLINE\n4400,\"line\n…\",java,[line…\n4321,java,syste…,/usr/…\n"
+		if got := buf.String(); got != want {
+			t.Errorf("\nwant %q\ngot  %q", want, got)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"golang.org/x/sys/unix"
+)
+
+// makeRaw places the terminal associated with the specified file descriptor
+// into raw mode, disabling line buffering, echo and signal generation for
+// control characters. The returned function restores the original terminal
+// settings.
+func makeRaw(fd int) (func() error, error) {
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terminal settings: %w", err)
+	}
+
+	original := *termios
+
+	// Equivalent to cfmakeraw(3) with the exception of output processing,
+	// which is retained so that newlines are translated as usual.
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP |
+		unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, termios); err != nil {
+		return nil, fmt.Errorf("failed to update terminal settings: %w", err)
+	}
+
+	restore := func() error {
+		if err := unix.IoctlSetTermios(fd, unix.TCSETS, &original); err != nil {
+			return fmt.Errorf("failed to restore terminal settings: %w", err)
+		}
+
+		return nil
+	}
+
+	return restore, nil
+}
+
+// terminalSize returns the width and height (in characters) of the terminal
+// associated with the specified file descriptor.
+func terminalSize(fd int) (int, int, error) {
+	ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read terminal size: %w", err)
+	}
+
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// openTerminalInput returns a file reading from the terminal associated with
+// the specified file descriptor. Pending reads from the returned file are
+// interrupted by setting a read deadline. The returned function closes the
+// file and restores blocking mode for the terminal.
+func openTerminalInput(fd int) (*os.File, func() error, error) {
+	dup, err := unix.Dup(fd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to duplicate terminal descriptor: %w", err)
+	}
+
+	// The file is only pollable, and so supports read deadlines, if the
+	// descriptor is in non-blocking mode. The mode is shared with the
+	// original descriptor.
+	if err := unix.SetNonblock(dup, true); err != nil {
+		_ = unix.Close(dup)
+
+		return nil, nil, fmt.Errorf("failed to update terminal descriptor: %w", err)
+	}
+
+	input := os.NewFile(uintptr(dup), "/dev/tty")
+
+	closeInput := func() error {
+		if err := input.Close(); err != nil {
+			return fmt.Errorf("failed to close terminal input: %w", err)
+		}
+
+		if err := unix.SetNonblock(fd, false); err != nil {
+			return fmt.Errorf("failed to restore terminal descriptor: %w", err)
+		}
+
+		return nil
+	}
+
+	return input, closeInput, nil
+}
+
+// notifyResize relays terminal window size changes to the specified
+// channel.
+func notifyResize(c chan<- os.Signal) {
+	signal.Notify(c, unix.SIGWINCH)
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// makeRaw is a stub for operating systems where the interactive view is not
+// supported.
+func makeRaw(_ int) (func() error, error) {
+	return nil, fmt.Errorf(
+		"failed to configure terminal on %s: %w",
+		runtime.GOOS,
+		procstate.ErrUnsupportedOS,
+	)
+}
+
+// terminalSize is a stub for operating systems where the interactive view
+// is not supported.
+func terminalSize(_ int) (int, int, error) {
+	return 0, 0, fmt.Errorf(
+		"failed to read terminal size on %s: %w",
+		runtime.GOOS,
+		procstate.ErrUnsupportedOS,
+	)
+}
+
+// openTerminalInput is a stub for operating systems where the interactive
+// view is not supported.
+func openTerminalInput(_ int) (*os.File, func() error, error) {
+	return nil, nil, fmt.Errorf(
+		"failed to open terminal input on %s: %w",
+		runtime.GOOS,
+		procstate.ErrUnsupportedOS,
+	)
+}
+
+// notifyResize is a stub for operating systems where the interactive view
+// is not supported. Window size changes are not relayed.
+func notifyResize(_ chan<- os.Signal) {}
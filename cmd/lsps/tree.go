@@ -0,0 +1,180 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/atc0005/check-process/internal/config"
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// ANSI escape sequences used to color output.
+const (
+	ansiReset  string = "\x1b[0m"
+	ansiBold   string = "\x1b[1m"
+	ansiRed    string = "\x1b[31m"
+	ansiYellow string = "\x1b[33m"
+)
+
+// Prefixes used to draw the branches of a process tree.
+const (
+	treeBranch     string = "├── "
+	treeLastBranch string = "└── "
+	treeIndent     string = "│   "
+	treeLastIndent string = "    "
+)
+
+// treeItem is a line of a rendered process tree. Identical sibling
+// processes without children are collapsed into a single item.
+type treeItem struct {
+	node  *procstate.ProcessNode
+	count int
+}
+
+// writeTreeOutput collects processes using the given Collector and writes
+// the process hierarchy to the specified io.Writer. Only branches containing
+// processes matching the given predicate are written; of those, only
+// branches containing problem processes are written unless all processes
+// are requested.
+func writeTreeOutput(w io.Writer, cfg *config.Config, collector *procstate.Collector, isListed procstate.Predicate) error {
+	processes, err := collector.Collect()
+	if err != nil {
+		return err
+	}
+
+	isProblem := procstate.ByState(procstate.KnownProblemProcessStates()...)
+
+	roots := processes.Tree()
+
+	switch {
+	case cfg.InspectorSettings.ShowAll:
+		roots = procstate.PruneTree(roots, isListed)
+		_, _ = fmt.Fprintf(w, "Process tree:\n\n")
+	default:
+		roots = procstate.PruneTree(roots, procstate.And(isListed, isProblem))
+		_, _ = fmt.Fprintf(w, "Problematic process tree:\n\n")
+	}
+
+	if len(roots) == 0 {
+		_, _ = fmt.Fprintf(w, "  - None\n")
+
+		return nil
+	}
+
+	color := useColor(w)
+
+	// Root nodes are written without branch lines.
+	for _, item := range collapseSiblings(roots) {
+		_, _ = fmt.Fprintln(w, treeItemLabel(item, isProblem, color))
+		writeTreeNodes(w, item.node.Children, "", isProblem, color)
+	}
+
+	return nil
+}
+
+// writeTreeNodes writes the given sibling nodes and their descendants to the
+// specified io.Writer, prefixing each line with the given branch prefix.
+func writeTreeNodes(w io.Writer, nodes []*procstate.ProcessNode, prefix string, isProblem procstate.Predicate, color bool) {
+	items := collapseSiblings(nodes)
+
+	for i, item := range items {
+		branch, indent := treeBranch, treeIndent
+		if i == len(items)-1 {
+			branch, indent = treeLastBranch, treeLastIndent
+		}
+
+		_, _ = fmt.Fprintf(w, "%s%s%s\n", prefix, branch, treeItemLabel(item, isProblem, color))
+
+		writeTreeNodes(w, item.node.Children, prefix+indent, isProblem, color)
+	}
+}
+
+// collapseSiblings groups the given sibling nodes, collapsing nodes without
+// children which share the same process name and state into a single item.
+// Items are returned in the order the first node of each was found.
+func collapseSiblings(nodes []*procstate.ProcessNode) []treeItem {
+	type siblingKey struct {
+		name  string
+		state string
+	}
+
+	items := make([]treeItem, 0, len(nodes))
+	index := make(map[siblingKey]int)
+
+	for _, node := range nodes {
+		if len(node.Children) > 0 {
+			items = append(items, treeItem{node: node, count: 1})
+			continue
+		}
+
+		key := siblingKey{name: node.Process.Name, state: node.Process.State.String()}
+		if i, ok := index[key]; ok {
+			items[i].count++
+			continue
+		}
+
+		index[key] = len(items)
+		items = append(items, treeItem{node: node, count: 1})
+	}
+
+	return items
+}
+
+// treeItemLabel returns the label written for the given item of a process
+// tree. Problem processes are colored by severity if requested.
+func treeItemLabel(item treeItem, isProblem procstate.Predicate, color bool) string {
+	p := item.node.Process
+
+	var label string
+	switch {
+	case item.count > 1:
+		label = fmt.Sprintf("%s ×%d [%s]", p.Name, item.count, p.State)
+	default:
+		label = fmt.Sprintf("%s (%d) [%s]", p.Name, p.Pid, p.State)
+	}
+
+	if color {
+		switch p.State.Severity {
+		case procstate.SeverityCritical:
+			label = ansiBold + ansiRed + label + ansiReset
+		case procstate.SeverityWarning:
+			label = ansiBold + ansiYellow + label + ansiReset
+		}
+	}
+
+	if problems := item.node.CountFunc(isProblem); problems > 0 {
+		label += fmt.Sprintf(" [Problem descendants: %d]", problems)
+	}
+
+	return label
+}
+
+// useColor indicates whether ANSI color sequences should be written to the
+// specified io.Writer. Color is only used for files which are terminals
+// unless disabled using the NO_COLOR environment variable (see
+// https://no-color.org/).
+func useColor(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
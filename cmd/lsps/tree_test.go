@@ -0,0 +1,80 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/atc0005/check-process/pkg/procstate"
+)
+
+// TestCollapseSiblings asserts that sibling nodes without children sharing
+// a name and state are collapsed into a single item, in the order the first
+// node of each was found.
+func TestCollapseSiblings(t *testing.T) {
+	t.Parallel()
+
+	processes := procstate.Processes{
+		{Name: "php-fpm", Pid: 100, PPid: 1, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "php-fpm", Pid: 101, PPid: 100, State: procstate.KernelAnyProcessStateZombie},
+		{Name: "php-fpm", Pid: 102, PPid: 100, State: procstate.KernelAnyProcessStateZombie},
+		{Name: "php-fpm", Pid: 103, PPid: 100, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "php-fpm", Pid: 104, PPid: 100, State: procstate.KernelAnyProcessStateZombie},
+		{Name: "php-fpm", Pid: 105, PPid: 100, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "php-fpm", Pid: 106, PPid: 100, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "sh", Pid: 107, PPid: 100, State: procstate.KernelAnyProcessStateSleeping},
+		{Name: "php-fpm", Pid: 200, PPid: 106, State: procstate.KernelAnyProcessStateSleeping},
+	}
+
+	root := processes.Tree()[0]
+
+	// php-fpm 106 has a child process, so it is not collapsed.
+	want := []string{
+		"php-fpm (101) x3",
+		"php-fpm (103) x2",
+		"php-fpm (106) x1",
+		"sh (107) x1",
+	}
+
+	items := collapseSiblings(root.Children)
+	got := make([]string, 0, len(items))
+	for _, item := range items {
+		got = append(got, fmt.Sprintf("%s (%d) x%d", item.node.Process.Name, item.node.Process.Pid, item.count))
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("\nwant %q\ngot  %q", want, got)
+	}
+}
+
+// TestUseColor asserts that color is not used for writers which are not
+// terminals (e.g., when tree output is redirected to a file or buffer).
+func TestUseColor(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "tree.txt"))
+	if err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if useColor(f) {
+		t.Errorf("want no color for regular file")
+	}
+
+	if useColor(&bytes.Buffer{}) {
+		t.Errorf("want no color for buffer")
+	}
+}
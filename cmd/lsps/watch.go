@@ -0,0 +1,193 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/rs/zerolog"
+)
+
+// watchTimeFormat is the format used for the timestamp of each line written
+// in watch mode.
+const watchTimeFormat string = time.RFC3339
+
+// watchState is the state retained between samples in watch mode.
+type watchState struct {
+	// isListed selects the processes to evaluate.
+	isListed procstate.Predicate
+
+	// problems is the index of problem processes found by the previous
+	// sample.
+	problems map[procstate.ProcessKey]procstate.Process
+
+	// tally is the per-state tally written by the most recent header.
+	tally string
+}
+
+// runWatch repeatedly samples processes using the given Collector at the
+// specified interval until interrupted (e.g., by Ctrl-C). For each sample, a
+// header with the per-state tally is written if the tally changed, followed
+// by a line for each process which entered or left a problem state since
+// the previous sample. Problem processes found by the first sample are
+// listed as having entered a problem state. Only processes matching the
+// given predicate are evaluated.
+func runWatch(
+	w io.Writer,
+	logger zerolog.Logger,
+	collector *procstate.Collector,
+	isListed procstate.Predicate,
+	interval time.Duration,
+) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	state := watchState{
+		isListed: isListed,
+		problems: make(map[procstate.ProcessKey]procstate.Process),
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := state.sample(w, logger, collector, time.Now()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Debug().Msg("Watch mode interrupted")
+
+			return nil
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// sample collects processes using the given Collector and writes the
+// changes since the previous sample to the specified io.Writer.
+func (s *watchState) sample(w io.Writer, logger zerolog.Logger, collector *procstate.Collector, now time.Time) error {
+	snapshot, err := collector.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	for _, procErr := range snapshot.ProcessErrors() {
+		// A process which could not be evaluated in this sample is
+		// likely to be evaluated by the next one.
+		logger.Warn().Err(procErr).Msg("Failed to evaluate process")
+	}
+
+	processes := snapshot.Processes.Filter(s.isListed)
+	timestamp := now.Format(watchTimeFormat)
+
+	if tally := strings.Join(processes.SummaryList(), ", "); tally != s.tally {
+		_, _ = fmt.Fprintf(w, "%s  == Processes: %d | %s\n", timestamp, len(processes), tally)
+		s.tally = tally
+	}
+
+	isProblem := procstate.ByState(procstate.KnownProblemProcessStates()...)
+
+	current := make(map[procstate.ProcessKey]procstate.Process, len(processes))
+	problems := make(map[procstate.ProcessKey]procstate.Process)
+	for _, p := range processes {
+		current[p.Key()] = p
+		if isProblem(p) {
+			problems[p.Key()] = p
+		}
+	}
+
+	// Processes are listed in process ID order for each kind of change.
+	for _, key := range sortedKeys(problems) {
+		p := problems[key]
+		prev, found := s.problems[key]
+
+		switch {
+		case !found:
+			_, _ = fmt.Fprintf(
+				w,
+				"%s  + entered  Name: %s [Pid: %d, PPid: %d, State: %s]\n",
+				timestamp,
+				p.Name,
+				p.Pid,
+				p.PPid,
+				p.State,
+			)
+
+		case !prev.State.Equal(p.State):
+			_, _ = fmt.Fprintf(
+				w,
+				"%s  ~ changed  Name: %s [Pid: %d, PPid: %d, State: %s -> %s]\n",
+				timestamp,
+				p.Name,
+				p.Pid,
+				p.PPid,
+				prev.State,
+				p.State,
+			)
+		}
+	}
+
+	for _, key := range sortedKeys(s.problems) {
+		if _, found := problems[key]; found {
+			continue
+		}
+
+		prev := s.problems[key]
+
+		newState := "exited"
+		if p, found := current[key]; found {
+			newState = p.State.String()
+		}
+
+		_, _ = fmt.Fprintf(
+			w,
+			"%s  - left     Name: %s [Pid: %d, PPid: %d, State: %s -> %s]\n",
+			timestamp,
+			prev.Name,
+			prev.Pid,
+			prev.PPid,
+			prev.State,
+			newState,
+		)
+	}
+
+	s.problems = problems
+
+	return nil
+}
+
+// sortedKeys returns the keys of the given process index sorted by process
+// ID and start time.
+func sortedKeys(index map[procstate.ProcessKey]procstate.Process) []procstate.ProcessKey {
+	keys := make([]procstate.ProcessKey, 0, len(index))
+	for key := range index {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b procstate.ProcessKey) int {
+		return cmp.Or(
+			cmp.Compare(a.Pid, b.Pid),
+			cmp.Compare(a.StartTime, b.StartTime),
+		)
+	})
+
+	return keys
+}
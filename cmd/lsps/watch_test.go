@@ -0,0 +1,133 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/rs/zerolog"
+)
+
+// TestWatchStateSample asserts that each sample reports the processes which
+// entered, changed or left a problem state since the previous sample and
+// that the tally header is only written when the tally changes.
+func TestWatchStateSample(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+	procDir := writeTestProcess(t, procRoot, 4321, "rhel6-disk-sleep")
+	writeTestProcess(t, procRoot, 389, "rhel7-kthread")
+
+	statusFile := filepath.Join(procDir, procstate.ProcStatusFilename)
+	status, err := os.ReadFile(statusFile)
+	if err != nil {
+		t.Fatalf("failed to read status file: %v", err)
+	}
+
+	setState := func(state string) {
+		data := strings.Replace(string(status), "State:\tD (disk sleep)", "State:\t"+state, 1)
+		if err := os.WriteFile(statusFile, []byte(data), 0o600); err != nil {
+			t.Fatalf("failed to write status file: %v", err)
+		}
+	}
+
+	collector, err := procstate.NewCollector(procstate.WithProcRoot(procRoot))
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	// The kernel thread is not evaluated.
+	state := watchState{
+		isListed: procstate.Not(procstate.ByPid(389)),
+		problems: make(map[procstate.ProcessKey]procstate.Process),
+	}
+
+	now := time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)
+	timestamp := now.Format(watchTimeFormat)
+
+	steps := []struct {
+		name   string
+		update func()
+		want   []string
+	}{
+		{
+			name: "first sample",
+			want: []string{
+				"== Processes: 1 | D (disk sleep) [1]",
+				"+ entered  Name: java [Pid: 4321, PPid: 1, State: D (disk sleep)]",
+			},
+		},
+		{
+			name: "unchanged",
+		},
+		{
+			name:   "problem state changed",
+			update: func() { setState("Z (zombie)") },
+			want: []string{
+				"== Processes: 1 | Z (zombie) [1]",
+				"~ changed  Name: java [Pid: 4321, PPid: 1, State: D (disk sleep) -> Z (zombie)]",
+			},
+		},
+		{
+			name:   "left problem state",
+			update: func() { setState("S (sleeping)") },
+			want: []string{
+				"== Processes: 1 | S (sleeping) [1]",
+				"- left     Name: java [Pid: 4321, PPid: 1, State: Z (zombie) -> S (sleeping)]",
+			},
+		},
+		{
+			name:   "entered problem state again",
+			update: func() { setState("D (disk sleep)") },
+			want: []string{
+				"== Processes: 1 | D (disk sleep) [1]",
+				"+ entered  Name: java [Pid: 4321, PPid: 1, State: D (disk sleep)]",
+			},
+		},
+		{
+			name: "exited",
+			update: func() {
+				if err := os.RemoveAll(procDir); err != nil {
+					t.Fatalf("failed to remove proc directory: %v", err)
+				}
+			},
+			want: []string{
+				"== Processes: 0 | ",
+				"- left     Name: java [Pid: 4321, PPid: 1, State: D (disk sleep) -> exited]",
+			},
+		},
+	}
+
+	// The steps depend on the state retained by the previous sample and
+	// are run in order.
+	for _, step := range steps {
+		if step.update != nil {
+			step.update()
+		}
+
+		var buf bytes.Buffer
+		if err := state.sample(&buf, zerolog.Nop(), collector, now); err != nil {
+			t.Fatalf("%s: failed to sample processes: %v", step.name, err)
+		}
+
+		var want string
+		for _, line := range step.want {
+			want += timestamp + "  " + line + "\n"
+		}
+
+		if got := buf.String(); got != want {
+			t.Errorf("%s:\nwant %q\ngot  %q", step.name, want, got)
+		}
+	}
+}
@@ -13,7 +13,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -40,6 +43,135 @@ var (
 	ErrConfigNotInitialized = errors.New("configuration not initialized")
 )
 
+// multiValueStringFlag is a custom type that satisfies the flag.Value
+// interface in order to accept multiple string values for some of our flags.
+// Values may be specified by repeating the flag or as a comma-separated
+// list.
+type multiValueStringFlag []string
+
+// String returns a comma separated string consisting of all slice elements.
+func (mvs *multiValueStringFlag) String() string {
+
+	// From the `flag` package docs:
+	// "The flag package may call the String method with a zero-valued
+	// receiver, such as a nil pointer."
+	if mvs == nil {
+		return ""
+	}
+
+	return strings.Join(*mvs, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (mvs *multiValueStringFlag) Set(value string) error {
+
+	// Split comma-separated string into multiple values, toss whitespace,
+	// skip empty values.
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		*mvs = append(*mvs, item)
+	}
+
+	return nil
+}
+
+// multiValueRegexFlag is a custom type that satisfies the flag.Value
+// interface in order to accept multiple regular expression values for some
+// of our flags. Unlike multiValueStringFlag, values are not split on commas
+// (which are valid within a regular expression); the flag is repeated
+// instead. Each value is validated as a regular expression when set.
+type multiValueRegexFlag []*regexp.Regexp
+
+// String returns a comma separated string consisting of all slice elements.
+func (mvr *multiValueRegexFlag) String() string {
+	if mvr == nil {
+		return ""
+	}
+
+	patterns := make([]string, 0, len(*mvr))
+	for _, re := range *mvr {
+		patterns = append(patterns, re.String())
+	}
+
+	return strings.Join(patterns, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (mvr *multiValueRegexFlag) Set(value string) error {
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: invalid regular expression %q: %v",
+			ErrUnsupportedOption,
+			value,
+			err,
+		)
+	}
+
+	*mvr = append(*mvr, re)
+
+	return nil
+}
+
+// multiValueIntFlag is a custom type that satisfies the flag.Value
+// interface in order to accept multiple whole number values for some of our
+// flags. Values may be repeated or specified as a comma-separated list.
+type multiValueIntFlag []int
+
+// String returns a comma separated string consisting of all slice elements.
+func (mvi *multiValueIntFlag) String() string {
+	if mvi == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(*mvi))
+	for _, n := range *mvi {
+		values = append(values, strconv.Itoa(n))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (mvi *multiValueIntFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return fmt.Errorf(
+				"%w: invalid number %q",
+				ErrUnsupportedOption,
+				item,
+			)
+		}
+
+		*mvi = append(*mvi, n)
+	}
+
+	return nil
+}
+
+// SortKey is a column used to sort listed processes along with the sort
+// direction.
+type SortKey struct {
+	// Column is the name of the column.
+	Column string
+
+	// Descending indicates whether processes are sorted in descending
+	// order.
+	Descending bool
+}
+
 // AppType represents the type of application that is being
 // configured/initialized. Not all application types will use the same
 // features and as a result will not accept the same flags. Unless noted
@@ -64,6 +196,69 @@ type InspectorSettings struct {
 	// ShowAll indicates whether the user opted to display information for ALL
 	// processes. This can produce a lot of output
 	ShowAll bool
+
+	// Output is the output format used when listing processes.
+	Output string
+
+	// Tree indicates whether the user opted to list the process hierarchy.
+	Tree bool
+
+	// Watch is the interval used to repeatedly sample processes. Watch mode
+	// is disabled if zero.
+	Watch time.Duration
+
+	// Interactive indicates whether the user opted to use the full-screen
+	// interactive view of processes.
+	Interactive bool
+
+	// Pids is the list of process IDs for processes to inspect in detail.
+	Pids multiValueIntFlag
+
+	// ExplainStates indicates whether the user opted to list the known
+	// process states and the severity assigned to each.
+	ExplainStates bool
+
+	// FilterStates is the list of single letter state codes of processes
+	// to list.
+	FilterStates multiValueStringFlag
+
+	// FilterNames is the list of names of processes to list.
+	FilterNames multiValueStringFlag
+
+	// FilterNameRegexes is the list of regular expressions matched against
+	// the names of processes to list.
+	FilterNameRegexes multiValueRegexFlag
+
+	// FilterUsers is the list of usernames or numeric user ID values of
+	// processes to list.
+	FilterUsers multiValueStringFlag
+
+	// FilterCgroups is the list of cgroup path substrings of processes to
+	// list.
+	FilterCgroups multiValueStringFlag
+
+	// FilterPPids is the list of parent process IDs of processes to list.
+	FilterPPids multiValueIntFlag
+
+	// Columns is the list of columns included with the table and csv
+	// output formats.
+	Columns multiValueStringFlag
+
+	// Sort is the list of columns (separated by SortKeySeparator) used to
+	// sort listed processes, each optionally prefixed with
+	// SortDescendingPrefix.
+	Sort string
+
+	// Truncate is the maximum width of values listed with the table and csv
+	// output formats. Zero disables truncation.
+	Truncate int
+
+	// Command is the command specified as the first non-flag argument. The
+	// default command (CommandList) lists processes.
+	Command string
+
+	// CommandArgs is the list of non-flag arguments following the command.
+	CommandArgs []string
 }
 
 // Config represents the application configuration as specified via
@@ -82,6 +277,14 @@ type Config struct {
 	// Log is an embedded zerolog Logger initialized via config.New().
 	Log zerolog.Logger
 
+	// KernelThreads controls how kernel threads are handled; they may be
+	// included with other processes, excluded or reported separately.
+	KernelThreads string
+
+	// Archive is the optional path to a capture archive evaluated instead of
+	// the live proc filesystem.
+	Archive string
+
 	// InspectorSettings is the collection of settings specific to the
 	// Inspector application type.
 	InspectorSettings InspectorSettings
@@ -93,6 +296,52 @@ type Config struct {
 	// their own branding output.
 	EmitBranding bool
 
+	// HungTaskSource is the optional source of kernel log messages used to
+	// annotate processes reported as blocked by the kernel hung task
+	// detector. This is either the kmsg keyword (for /dev/kmsg) or the path
+	// to a dmesg or journal export file.
+	HungTaskSource string
+
+	// UnexpectedStateSeverity is the severity applied if any processes are
+	// found in states not expected for the running kernel.
+	UnexpectedStateSeverity string
+
+	// ExcludeAncestors indicates whether the user opted to exclude the
+	// ancestor processes of the plugin (e.g., the monitoring agent or a
+	// wrapper shell script) in addition to the plugin process itself.
+	ExcludeAncestors bool
+
+	// ExcludeAgentChildren indicates whether the user opted to exclude the
+	// other child processes of the plugin's direct parent (e.g., zombies
+	// from previous plugin invocations). This implies ExcludeAncestors.
+	ExcludeAgentChildren bool
+
+	// IgnoreNames is the list of process names for known-benign processes
+	// to ignore when evaluating process states.
+	IgnoreNames multiValueStringFlag
+
+	// IgnoreNameRegexes is the list of regular expressions matched against
+	// process names for known-benign processes to ignore when evaluating
+	// process states.
+	IgnoreNameRegexes multiValueRegexFlag
+
+	// IgnoreCmdlineRegexes is the list of regular expressions matched
+	// against the full command line for known-benign processes to ignore
+	// when evaluating process states.
+	IgnoreCmdlineRegexes multiValueRegexFlag
+
+	// IgnoreUsers is the list of usernames or numeric user ID values for
+	// known-benign processes to ignore when evaluating process states.
+	IgnoreUsers multiValueStringFlag
+
+	// IgnoreParentNames is the list of parent process names for
+	// known-benign processes to ignore when evaluating process states.
+	IgnoreParentNames multiValueStringFlag
+
+	// IgnoreCgroups is the list of cgroup path substrings for known-benign
+	// processes to ignore when evaluating process states.
+	IgnoreCgroups multiValueStringFlag
+
 	// ShowVersion is a flag indicating whether the user opted to display only
 	// the version string and then immediately exit the application.
 	ShowVersion bool
@@ -7,6 +7,8 @@
 
 package config
 
+import "time"
+
 const myAppName string = "check-process"
 const myAppURL string = "https://github.com/atc0005/check-process"
 
@@ -17,14 +19,41 @@ const ExitCodeCatchall int = 1
 
 // Shared flag help text
 const (
-	versionFlagHelp  string = "Whether to display application version and then immediately exit application."
-	logLevelFlagHelp string = "Sets log level."
-	brandingFlagHelp string = "Toggles emission of branding details with plugin status details. This output is disabled by default."
-	helpFlagHelp     string = "Emit this help text"
+	versionFlagHelp       string = "Whether to display application version and then immediately exit application."
+	logLevelFlagHelp      string = "Sets log level."
+	brandingFlagHelp      string = "Toggles emission of branding details with plugin status details. This output is disabled by default."
+	helpFlagHelp          string = "Emit this help text"
+	kernelThreadsFlagHelp string = "Controls how kernel threads are handled. Kernel threads may be included with other processes, excluded from evaluation or reported separately from userland processes."
+	archiveFlagHelp       string = "Optional path to a capture archive (created using the lsps capture command) to evaluate instead of the live proc filesystem. Disabled by default."
 )
 
 const (
-	showAllProcessesFlagHelp string = "Toggles listing of all processes. WARNING: This may produce a LOT of output. Disabled by default."
+	showAllProcessesFlagHelp        string = "Toggles listing of all processes. WARNING: This may produce a LOT of output. Disabled by default."
+	outputFlagHelp                  string = "Output format used when listing processes."
+	columnsFlagHelp                 string = "Columns included with the table and csv output formats. May be repeated or specified as a comma-separated list."
+	sortFlagHelp                    string = "Comma-separated list of columns used to sort listed processes. Prefix a column name with - to sort in descending order. By default, processes are listed by process ID (grouped by state) with the text output format and in collection order with the table and csv output formats."
+	treeFlagHelp                    string = "Toggles listing of the process hierarchy. Only branches containing problem processes are listed unless all processes are requested. Disabled by default."
+	watchFlagHelp                   string = "Optional interval (e.g., 2s) used to repeatedly sample processes, listing only processes which entered or left a problem state since the previous sample. Use Ctrl-C to stop. Disabled by default."
+	interactiveFlagHelp             string = "Toggles a full-screen interactive view of processes which is refreshed periodically. Requires a terminal. Disabled by default."
+	pidFlagHelp                     string = "Process ID of a process to inspect in detail, listing all known details of the process and the severity check_process would assign. May be repeated or specified as a comma-separated list."
+	explainStatesFlagHelp           string = "Toggles listing of every process state known to this tool with the kernel versions which emit it, a plain-language explanation and the severity check_process would assign using the current configuration. Disabled by default."
+	stateFlagHelp                   string = "Single letter state code (e.g., D) of processes to list. May be repeated or specified as a comma-separated list. Filters are combined; only processes matching all specified filters are listed and summarized."
+	nameFlagHelp                    string = "Name of processes to list. May be repeated or specified as a comma-separated list."
+	nameRegexFlagHelp               string = "Regular expression matched against the names of processes to list. May be repeated."
+	userFlagHelp                    string = "Username or numeric user ID of processes to list. May be repeated or specified as a comma-separated list."
+	cgroupFlagHelp                  string = "Cgroup path substring of processes to list. May be repeated or specified as a comma-separated list."
+	ppidFlagHelp                    string = "Parent process ID of processes to list. May be repeated or specified as a comma-separated list."
+	truncateFlagHelp                string = "Maximum width of values listed with the table and csv output formats. Longer values are truncated. Zero disables truncation."
+	ignoreNameFlagHelp              string = "Name of a known-benign process to ignore when evaluating process states. Ignored processes are listed separately in the report. May be repeated or specified as a comma-separated list."
+	ignoreNameRegexFlagHelp         string = "Regular expression matched against process names for known-benign processes to ignore when evaluating process states. May be repeated."
+	ignoreCmdlineRegexFlagHelp      string = "Regular expression matched against the full process command line for known-benign processes to ignore when evaluating process states. May be repeated."
+	ignoreUserFlagHelp              string = "Username or numeric user ID for known-benign processes to ignore when evaluating process states. May be repeated or specified as a comma-separated list."
+	ignoreParentFlagHelp            string = "Parent process name for known-benign processes to ignore when evaluating process states. May be repeated or specified as a comma-separated list."
+	ignoreCgroupFlagHelp            string = "Cgroup path substring for known-benign processes to ignore when evaluating process states. May be repeated or specified as a comma-separated list."
+	excludeAncestorsFlagHelp        string = "Toggles exclusion of the ancestor processes of this plugin (e.g., the monitoring agent or a wrapper shell script) in addition to the plugin process itself. Disabled by default."
+	excludeAgentChildrenFlagHelp    string = "Toggles exclusion of other child processes of the direct parent of this plugin (e.g., zombies from previous plugin invocations not yet reaped by the monitoring agent). Children of more distant ancestors are still evaluated. Implies exclusion of ancestor processes. Disabled by default."
+	unexpectedStateSeverityFlagHelp string = "Severity applied if any processes are found in states not expected for the running kernel (e.g., unrecognized or malformed states)."
+	hungTaskSourceFlagHelp          string = "Optional source of kernel log messages used to annotate processes reported as blocked by the kernel hung task detector. Specify kmsg to read the kernel log buffer (/dev/kmsg) or the path to a dmesg or journal export file. Disabled by default."
 )
 
 // shorthandFlagSuffix is appended to short flag help text to emphasize that
@@ -34,24 +63,148 @@ const shorthandFlagSuffix = " (shorthand)"
 // Flag names for consistent references. Exported so that they're available
 // from tests.
 const (
-	HelpFlagLong             string = "help"
-	HelpFlagShort            string = "h"
-	VersionFlagLong          string = "version"
-	BrandingFlag             string = "branding"
-	TimeoutFlagLong          string = "timeout"
-	TimeoutFlagShort         string = "t"
-	LogLevelFlagLong         string = "log-level"
-	LogLevelFlagShort        string = "ll"
-	ShowAllProcessesFlagLong string = "show-all"
+	HelpFlagLong                    string = "help"
+	HelpFlagShort                   string = "h"
+	VersionFlagLong                 string = "version"
+	BrandingFlag                    string = "branding"
+	TimeoutFlagLong                 string = "timeout"
+	TimeoutFlagShort                string = "t"
+	LogLevelFlagLong                string = "log-level"
+	LogLevelFlagShort               string = "ll"
+	ShowAllProcessesFlagLong        string = "show-all"
+	KernelThreadsFlagLong           string = "kernel-threads"
+	HungTaskSourceFlagLong          string = "hung-task-source"
+	UnexpectedStateSeverityFlagLong string = "unexpected-state-severity"
+	ExcludeAncestorsFlagLong        string = "exclude-ancestors"
+	ExcludeAgentChildrenFlagLong    string = "exclude-agent-children"
+	IgnoreNameFlagLong              string = "ignore-name"
+	IgnoreNameRegexFlagLong         string = "ignore-name-regex"
+	IgnoreCmdlineRegexFlagLong      string = "ignore-cmdline-regex"
+	IgnoreUserFlagLong              string = "ignore-user"
+	IgnoreParentFlagLong            string = "ignore-parent"
+	IgnoreCgroupFlagLong            string = "ignore-cgroup"
+	ArchiveFlagLong                 string = "archive"
+	OutputFlagLong                  string = "output"
+	ColumnsFlagLong                 string = "columns"
+	SortFlagLong                    string = "sort"
+	TruncateFlagLong                string = "truncate"
+	TreeFlagLong                    string = "tree"
+	WatchFlagLong                   string = "watch"
+	InteractiveFlagLong             string = "interactive"
+	PidFlagLong                     string = "pid"
+	ExplainStatesFlagLong           string = "explain-states"
+	StateFlagLong                   string = "state"
+	NameFlagLong                    string = "name"
+	NameRegexFlagLong               string = "name-regex"
+	UserFlagLong                    string = "user"
+	CgroupFlagLong                  string = "cgroup"
+	PPidFlagLong                    string = "ppid"
 )
 
 // Default flag settings if not overridden by user input
 const (
-	defaultHelp                  bool   = false
-	defaultLogLevel              string = "info"
-	defaultEmitBranding          bool   = false
-	defaultDisplayVersionAndExit bool   = false
-	defaultShowAllProcesses      bool   = false
+	defaultHelp                    bool   = false
+	defaultLogLevel                string = "info"
+	defaultEmitBranding            bool   = false
+	defaultDisplayVersionAndExit   bool   = false
+	defaultShowAllProcesses        bool   = false
+	defaultKernelThreads           string = KernelThreadsInclude
+	defaultHungTaskSource          string = ""
+	defaultUnexpectedStateSeverity string = SeverityWarning
+	defaultExcludeAncestors        bool   = false
+	defaultExcludeAgentChildren    bool   = false
+	defaultArchive                 string = ""
+	defaultOutput                  string = OutputText
+	defaultSort                    string = ""
+	defaultTruncate                int    = 0
+	defaultTree                    bool   = false
+	defaultInteractive             bool   = false
+	defaultExplainStates           bool   = false
+
+	// Watch mode is disabled by default.
+	defaultWatch time.Duration = 0
+)
+
+// Supported severity values for user-configurable evaluation results.
+const (
+	SeverityOK       string = "ok"
+	SeverityWarning  string = "warning"
+	SeverityCritical string = "critical"
+	SeverityUnknown  string = "unknown"
+)
+
+// Supported kernel thread handling modes.
+const (
+	// KernelThreadsInclude indicates that kernel threads are evaluated and
+	// reported along with userland processes.
+	KernelThreadsInclude string = "include"
+
+	// KernelThreadsExclude indicates that kernel threads are excluded from
+	// evaluation and reports.
+	KernelThreadsExclude string = "exclude"
+
+	// KernelThreadsSeparate indicates that kernel threads are evaluated, but
+	// reported separately from userland processes.
+	KernelThreadsSeparate string = "separate"
+)
+
+// MinWatchInterval is the shortest supported interval for sampling
+// processes in watch mode.
+const MinWatchInterval time.Duration = 100 * time.Millisecond
+
+// Supported Inspector application output formats.
+const (
+	// OutputText is the default human readable output format.
+	OutputText string = "text"
+
+	// OutputJSON is a JSON document using a documented schema intended for
+	// use by other tools.
+	OutputJSON string = "json"
+
+	// OutputTable is a table of aligned columns.
+	OutputTable string = "table"
+
+	// OutputCSV is comma-separated values with a header row.
+	OutputCSV string = "csv"
+)
+
+// Supported columns for the table and csv output formats.
+const (
+	ColumnPid       string = "pid"
+	ColumnPPid      string = "ppid"
+	ColumnName      string = "name"
+	ColumnParent    string = "parent"
+	ColumnState     string = "state"
+	ColumnCategory  string = "category"
+	ColumnSeverity  string = "severity"
+	ColumnThreads   string = "threads"
+	ColumnVMRSS     string = "vmrss"
+	ColumnVMSwap    string = "vmswap"
+	ColumnUser      string = "user"
+	ColumnKThread   string = "kthread"
+	ColumnStartTime string = "starttime"
+	ColumnCmdline   string = "cmdline"
+)
+
+// SortDescendingPrefix is the prefix applied to a sort column name to sort
+// in descending order.
+const SortDescendingPrefix string = "-"
+
+// SortKeySeparator separates the columns used to sort listed processes.
+const SortKeySeparator string = ","
+
+// Supported Inspector application commands. Commands are specified as the
+// first non-flag argument.
+const (
+	// CommandList is the default command; processes are listed.
+	CommandList string = ""
+
+	// CommandCapture captures the proc filesystem to an archive file.
+	CommandCapture string = "capture"
+
+	// CommandDiff compares the processes of two snapshot or capture archive
+	// files.
+	CommandDiff string = "diff"
 )
 
 const (
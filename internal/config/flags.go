@@ -55,11 +55,64 @@ func (c *Config) handleFlagsConfig(appType AppType) error {
 		supportedValuesFlagHelpText(logLevelFlagHelp, supportedLogLevels()),
 	)
 
+	c.flagSet.StringVar(
+		&c.KernelThreads,
+		KernelThreadsFlagLong,
+		defaultKernelThreads,
+		supportedValuesFlagHelpText(kernelThreadsFlagHelp, supportedKernelThreadsModes()),
+	)
+
+	c.flagSet.StringVar(&c.Archive, ArchiveFlagLong, defaultArchive, archiveFlagHelp)
+
+	c.flagSet.StringVar(
+		&c.UnexpectedStateSeverity,
+		UnexpectedStateSeverityFlagLong,
+		defaultUnexpectedStateSeverity,
+		supportedValuesFlagHelpText(unexpectedStateSeverityFlagHelp, supportedSeverities()),
+	)
+
 	switch {
 	case appType.Inspector:
 		c.flagSet.BoolVar(&c.InspectorSettings.ShowAll, ShowAllProcessesFlagLong, defaultShowAllProcesses, showAllProcessesFlagHelp)
+		c.flagSet.StringVar(
+			&c.InspectorSettings.Output,
+			OutputFlagLong,
+			defaultOutput,
+			supportedValuesFlagHelpText(outputFlagHelp, supportedOutputFormats()),
+		)
+		c.flagSet.Var(
+			&c.InspectorSettings.Columns,
+			ColumnsFlagLong,
+			supportedValuesFlagHelpText(columnsFlagHelp, supportedColumns()),
+		)
+		c.flagSet.BoolVar(&c.InspectorSettings.Tree, TreeFlagLong, defaultTree, treeFlagHelp)
+		c.flagSet.DurationVar(&c.InspectorSettings.Watch, WatchFlagLong, defaultWatch, watchFlagHelp)
+		c.flagSet.BoolVar(&c.InspectorSettings.Interactive, InteractiveFlagLong, defaultInteractive, interactiveFlagHelp)
+		c.flagSet.Var(&c.InspectorSettings.Pids, PidFlagLong, pidFlagHelp)
+		c.flagSet.BoolVar(&c.InspectorSettings.ExplainStates, ExplainStatesFlagLong, defaultExplainStates, explainStatesFlagHelp)
+		c.flagSet.Var(
+			&c.InspectorSettings.FilterStates,
+			StateFlagLong,
+			supportedValuesFlagHelpText(stateFlagHelp, supportedStateCodes()),
+		)
+		c.flagSet.Var(&c.InspectorSettings.FilterNames, NameFlagLong, nameFlagHelp)
+		c.flagSet.Var(&c.InspectorSettings.FilterNameRegexes, NameRegexFlagLong, nameRegexFlagHelp)
+		c.flagSet.Var(&c.InspectorSettings.FilterUsers, UserFlagLong, userFlagHelp)
+		c.flagSet.Var(&c.InspectorSettings.FilterCgroups, CgroupFlagLong, cgroupFlagHelp)
+		c.flagSet.Var(&c.InspectorSettings.FilterPPids, PPidFlagLong, ppidFlagHelp)
+		c.flagSet.StringVar(&c.InspectorSettings.Sort, SortFlagLong, defaultSort, sortFlagHelp)
+		c.flagSet.IntVar(&c.InspectorSettings.Truncate, TruncateFlagLong, defaultTruncate, truncateFlagHelp)
 	case appType.Plugin:
 		c.flagSet.BoolVar(&c.EmitBranding, BrandingFlag, defaultEmitBranding, brandingFlagHelp)
+		c.flagSet.StringVar(&c.HungTaskSource, HungTaskSourceFlagLong, defaultHungTaskSource, hungTaskSourceFlagHelp)
+		c.flagSet.Var(&c.IgnoreNames, IgnoreNameFlagLong, ignoreNameFlagHelp)
+		c.flagSet.Var(&c.IgnoreNameRegexes, IgnoreNameRegexFlagLong, ignoreNameRegexFlagHelp)
+		c.flagSet.Var(&c.IgnoreCmdlineRegexes, IgnoreCmdlineRegexFlagLong, ignoreCmdlineRegexFlagHelp)
+		c.flagSet.Var(&c.IgnoreUsers, IgnoreUserFlagLong, ignoreUserFlagHelp)
+		c.flagSet.Var(&c.IgnoreParentNames, IgnoreParentFlagLong, ignoreParentFlagHelp)
+		c.flagSet.Var(&c.IgnoreCgroups, IgnoreCgroupFlagLong, ignoreCgroupFlagHelp)
+		c.flagSet.BoolVar(&c.ExcludeAncestors, ExcludeAncestorsFlagLong, defaultExcludeAncestors, excludeAncestorsFlagHelp)
+		c.flagSet.BoolVar(&c.ExcludeAgentChildren, ExcludeAgentChildrenFlagLong, defaultExcludeAgentChildren, excludeAgentChildrenFlagHelp)
 	}
 
 	// Allow our function to override the default Help output.
@@ -70,6 +123,17 @@ func (c *Config) handleFlagsConfig(appType AppType) error {
 	c.flagSet.Usage = Usage(c.flagSet, os.Stdout)
 
 	// parse flag definitions from the argument list
-	return c.flagSet.Parse(os.Args[1:])
+	if err := c.flagSet.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+
+	// Inspector applications accept a command and command arguments
+	// following any flags.
+	if appType.Inspector && c.flagSet.NArg() > 0 {
+		c.InspectorSettings.Command = c.flagSet.Arg(0)
+		c.InspectorSettings.CommandArgs = c.flagSet.Args()[1:]
+	}
+
+	return nil
 
 }
@@ -7,6 +7,14 @@
 
 package config
 
+import (
+	"slices"
+	"strings"
+
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/atc0005/go-nagios"
+)
+
 // supportedLogLevels returns a list of valid log levels supported by tools in
 // this project.
 func supportedLogLevels() []string {
@@ -21,3 +29,187 @@ func supportedLogLevels() []string {
 		LogLevelTrace,
 	}
 }
+
+// supportedKernelThreadsModes returns a list of valid kernel thread handling
+// modes supported by tools in this project.
+func supportedKernelThreadsModes() []string {
+	return []string{
+		KernelThreadsInclude,
+		KernelThreadsExclude,
+		KernelThreadsSeparate,
+	}
+}
+
+// supportedCommands returns a list of valid commands supported by
+// Inspector applications in this project.
+func supportedCommands() []string {
+	return []string{
+		CommandList,
+		CommandCapture,
+		CommandDiff,
+	}
+}
+
+// supportedOutputFormats returns a list of valid output formats supported
+// by Inspector applications in this project.
+func supportedOutputFormats() []string {
+	return []string{
+		OutputText,
+		OutputJSON,
+		OutputTable,
+		OutputCSV,
+	}
+}
+
+// supportedColumns returns a list of valid columns for the table and csv
+// output formats supported by Inspector applications in this project.
+func supportedColumns() []string {
+	return []string{
+		ColumnPid,
+		ColumnPPid,
+		ColumnName,
+		ColumnParent,
+		ColumnState,
+		ColumnCategory,
+		ColumnSeverity,
+		ColumnThreads,
+		ColumnVMRSS,
+		ColumnVMSwap,
+		ColumnUser,
+		ColumnKThread,
+		ColumnStartTime,
+		ColumnCmdline,
+	}
+}
+
+// defaultColumns returns the list of columns included with the table and
+// csv output formats if not specified.
+func defaultColumns() []string {
+	return []string{
+		ColumnPid,
+		ColumnPPid,
+		ColumnName,
+		ColumnState,
+		ColumnThreads,
+		ColumnVMSwap,
+	}
+}
+
+// OutputColumns returns the list of columns included with the table and csv
+// output formats. The default columns are returned if not specified.
+func (c Config) OutputColumns() []string {
+	if len(c.InspectorSettings.Columns) == 0 {
+		return defaultColumns()
+	}
+
+	return c.InspectorSettings.Columns
+}
+
+// SortKeys returns the columns used to sort listed processes in order of
+// precedence. An empty list is returned if sorting was not requested.
+func (c Config) SortKeys() []SortKey {
+	var keys []SortKey
+	for _, item := range strings.Split(c.InspectorSettings.Sort, SortKeySeparator) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		column, descending := strings.CutPrefix(item, SortDescendingPrefix)
+		keys = append(keys, SortKey{Column: column, Descending: descending})
+	}
+
+	return keys
+}
+
+// supportedStateCodes returns a list of valid single letter state codes
+// used to filter listed processes. The list is derived from the process
+// states known to the procstate package.
+func supportedStateCodes() []string {
+	var codes []string
+	for _, state := range procstate.SupportedProcessStates() {
+		code := string(state.Code)
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes
+}
+
+// supportedSeverities returns a list of valid severity values for
+// user-configurable evaluation results.
+func supportedSeverities() []string {
+	return []string{
+		SeverityOK,
+		SeverityWarning,
+		SeverityCritical,
+		SeverityUnknown,
+	}
+}
+
+// UnexpectedStateServiceState returns the service state applied if any
+// processes are found in states not expected for the running kernel.
+func (c Config) UnexpectedStateServiceState() nagios.ServiceState {
+	switch strings.ToLower(c.UnexpectedStateSeverity) {
+	case SeverityOK:
+		return nagios.ServiceState{Label: nagios.StateOKLabel, ExitCode: nagios.StateOKExitCode}
+	case SeverityCritical:
+		return nagios.ServiceState{Label: nagios.StateCRITICALLabel, ExitCode: nagios.StateCRITICALExitCode}
+	case SeverityUnknown:
+		return nagios.ServiceState{Label: nagios.StateUNKNOWNLabel, ExitCode: nagios.StateUNKNOWNExitCode}
+	default:
+		return nagios.ServiceState{Label: nagios.StateWARNINGLabel, ExitCode: nagios.StateWARNINGExitCode}
+	}
+}
+
+// IgnoreRules returns the user-specified rules used to identify known-benign
+// processes which should be ignored when evaluating process states.
+func (c Config) IgnoreRules() procstate.IgnoreRules {
+	return procstate.IgnoreRules{
+		Names:          c.IgnoreNames,
+		NameRegexes:    c.IgnoreNameRegexes,
+		CmdlineRegexes: c.IgnoreCmdlineRegexes,
+		Users:          c.IgnoreUsers,
+		ParentNames:    c.IgnoreParentNames,
+		Cgroups:        c.IgnoreCgroups,
+	}
+}
+
+// Filters returns the user-specified filters used to select the processes
+// to list. Values for each filter are alternatives; a process is selected
+// if it matches any value of each of the specified filters. An empty list
+// is returned if no filters were specified.
+func (c Config) Filters() []procstate.Predicate {
+	var filters []procstate.Predicate
+
+	if states := c.InspectorSettings.FilterStates; len(states) > 0 {
+		codes := make([]byte, 0, len(states))
+		for _, state := range states {
+			codes = append(codes, state[0])
+		}
+		filters = append(filters, procstate.ByStateCode(codes...))
+	}
+
+	if names := c.InspectorSettings.FilterNames; len(names) > 0 {
+		filters = append(filters, procstate.ByName(names...))
+	}
+
+	if res := c.InspectorSettings.FilterNameRegexes; len(res) > 0 {
+		filters = append(filters, procstate.ByNameRegex(res...))
+	}
+
+	if users := c.InspectorSettings.FilterUsers; len(users) > 0 {
+		filters = append(filters, procstate.ByUser(users...))
+	}
+
+	if cgroups := c.InspectorSettings.FilterCgroups; len(cgroups) > 0 {
+		filters = append(filters, procstate.ByCgroup(cgroups...))
+	}
+
+	if ppids := c.InspectorSettings.FilterPPids; len(ppids) > 0 {
+		filters = append(filters, procstate.ByParent(ppids...))
+	}
+
+	return filters
+}
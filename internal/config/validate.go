@@ -11,11 +11,12 @@ import (
 	"fmt"
 
 	"github.com/atc0005/check-process/internal/textutils"
+	"github.com/atc0005/check-process/pkg/procstate"
 )
 
 // validate verifies all Config struct fields have been provided acceptable
 // values.
-func (c Config) validate(_ AppType) error {
+func (c Config) validate(appType AppType) error {
 
 	// Validate the specified logging level
 	supportedLogLevels := supportedLogLevels()
@@ -29,12 +30,313 @@ func (c Config) validate(_ AppType) error {
 		)
 	}
 
-	// 	switch {
-	// 	case appType.Inspector:
-	//
-	// 	case appType.Plugin:
-	//
-	// 	}
+	supportedKernelThreadsModes := supportedKernelThreadsModes()
+	if !textutils.InList(c.KernelThreads, supportedKernelThreadsModes, true) {
+		return fmt.Errorf(
+			"%w: invalid kernel threads mode;"+
+				" got %v, expected one of %v",
+			ErrUnsupportedOption,
+			c.KernelThreads,
+			supportedKernelThreadsModes,
+		)
+	}
+
+	supportedSeverities := supportedSeverities()
+	if !textutils.InList(c.UnexpectedStateSeverity, supportedSeverities, true) {
+		return fmt.Errorf(
+			"%w: invalid unexpected state severity;"+
+				" got %v, expected one of %v",
+			ErrUnsupportedOption,
+			c.UnexpectedStateSeverity,
+			supportedSeverities,
+		)
+	}
+
+	switch {
+	case appType.Inspector:
+		supportedCommands := supportedCommands()
+		if !textutils.InList(c.InspectorSettings.Command, supportedCommands, false) {
+			return fmt.Errorf(
+				"%w: invalid command;"+
+					" got %v, expected one of %q",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Command,
+				supportedCommands,
+			)
+		}
+
+		supportedOutputFormats := supportedOutputFormats()
+		if !textutils.InList(c.InspectorSettings.Output, supportedOutputFormats, false) {
+			return fmt.Errorf(
+				"%w: invalid output format;"+
+					" got %v, expected one of %v",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Output,
+				supportedOutputFormats,
+			)
+		}
+
+		if c.InspectorSettings.Tree && c.InspectorSettings.Output != OutputText {
+			return fmt.Errorf(
+				"%w: %s flag is only supported with the %s output format",
+				ErrUnsupportedOption,
+				TreeFlagLong,
+				OutputText,
+			)
+		}
+
+		switch watch := c.InspectorSettings.Watch; {
+		case watch == 0:
+			// Watch mode disabled.
+		case watch < MinWatchInterval:
+			return fmt.Errorf(
+				"%w: invalid watch interval;"+
+					" got %v, expected %v or greater",
+				ErrUnsupportedOption,
+				watch,
+				MinWatchInterval,
+			)
+		case c.InspectorSettings.Tree || c.InspectorSettings.ShowAll || c.InspectorSettings.Output != OutputText:
+			return fmt.Errorf(
+				"%w: %s flag is only supported with the %s output format"+
+					" and cannot be used with the %s or %s flags",
+				ErrUnsupportedOption,
+				WatchFlagLong,
+				OutputText,
+				TreeFlagLong,
+				ShowAllProcessesFlagLong,
+			)
+		}
+
+		if c.InspectorSettings.Interactive &&
+			(c.InspectorSettings.Tree || c.InspectorSettings.Watch > 0 || c.InspectorSettings.Output != OutputText) {
+			return fmt.Errorf(
+				"%w: %s flag is only supported with the %s output format"+
+					" and cannot be used with the %s or %s flags",
+				ErrUnsupportedOption,
+				InteractiveFlagLong,
+				OutputText,
+				TreeFlagLong,
+				WatchFlagLong,
+			)
+		}
+
+		for _, pid := range c.InspectorSettings.Pids {
+			if pid <= 0 {
+				return fmt.Errorf(
+					"%w: invalid process ID;"+
+						" got %v, expected a positive whole number",
+					ErrUnsupportedOption,
+					pid,
+				)
+			}
+		}
+
+		supportedStateCodes := supportedStateCodes()
+		for _, state := range c.InspectorSettings.FilterStates {
+			if !textutils.InList(state, supportedStateCodes, false) {
+				return fmt.Errorf(
+					"%w: invalid state code;"+
+						" got %v, expected one of %v",
+					ErrUnsupportedOption,
+					state,
+					supportedStateCodes,
+				)
+			}
+		}
+
+		for _, ppid := range c.InspectorSettings.FilterPPids {
+			if ppid < 0 {
+				return fmt.Errorf(
+					"%w: invalid parent process ID;"+
+						" got %v, expected zero or greater",
+					ErrUnsupportedOption,
+					ppid,
+				)
+			}
+		}
+
+		if len(c.InspectorSettings.Pids) > 0 && len(c.Filters()) > 0 {
+			return fmt.Errorf(
+				"%w: %s flag cannot be used with the %s, %s, %s, %s, %s or %s flags",
+				ErrUnsupportedOption,
+				PidFlagLong,
+				StateFlagLong,
+				NameFlagLong,
+				NameRegexFlagLong,
+				UserFlagLong,
+				CgroupFlagLong,
+				PPidFlagLong,
+			)
+		}
+
+		if len(c.InspectorSettings.Pids) > 0 &&
+			(c.InspectorSettings.Tree || c.InspectorSettings.Watch > 0 ||
+				c.InspectorSettings.Interactive || c.InspectorSettings.Output != OutputText) {
+			return fmt.Errorf(
+				"%w: %s flag is only supported with the %s output format"+
+					" and cannot be used with the %s, %s or %s flags",
+				ErrUnsupportedOption,
+				PidFlagLong,
+				OutputText,
+				TreeFlagLong,
+				WatchFlagLong,
+				InteractiveFlagLong,
+			)
+		}
+
+		if c.InspectorSettings.ExplainStates &&
+			(c.InspectorSettings.ShowAll || c.InspectorSettings.Tree || c.InspectorSettings.Watch > 0 ||
+				c.InspectorSettings.Interactive || len(c.InspectorSettings.Pids) > 0 ||
+				len(c.Filters()) > 0 || c.InspectorSettings.Output != OutputText) {
+			return fmt.Errorf(
+				"%w: %s flag is only supported with the %s output format"+
+					" and cannot be used with the %s, %s, %s, %s, %s or process filter flags",
+				ErrUnsupportedOption,
+				ExplainStatesFlagLong,
+				OutputText,
+				ShowAllProcessesFlagLong,
+				TreeFlagLong,
+				WatchFlagLong,
+				InteractiveFlagLong,
+				PidFlagLong,
+			)
+		}
+
+		supportedColumns := supportedColumns()
+		for _, column := range c.InspectorSettings.Columns {
+			if !textutils.InList(column, supportedColumns, false) {
+				return fmt.Errorf(
+					"%w: invalid column;"+
+						" got %v, expected one of %v",
+					ErrUnsupportedOption,
+					column,
+					supportedColumns,
+				)
+			}
+		}
+
+		for _, key := range c.SortKeys() {
+			if !textutils.InList(key.Column, supportedColumns, false) {
+				return fmt.Errorf(
+					"%w: invalid sort column;"+
+						" got %v, expected one of %v",
+					ErrUnsupportedOption,
+					key.Column,
+					supportedColumns,
+				)
+			}
+		}
+
+		if c.InspectorSettings.Truncate < 0 {
+			return fmt.Errorf(
+				"%w: invalid truncate width;"+
+					" got %v, expected zero or greater",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Truncate,
+			)
+		}
+
+		switch c.InspectorSettings.Command {
+		case CommandCapture:
+			if len(c.InspectorSettings.CommandArgs) > 1 {
+				return fmt.Errorf(
+					"%w: %s command accepts at most one output file argument;"+
+						" got %v",
+					ErrUnsupportedOption,
+					CommandCapture,
+					c.InspectorSettings.CommandArgs,
+				)
+			}
+
+		case CommandDiff:
+			if len(c.InspectorSettings.CommandArgs) != 2 {
+				return fmt.Errorf(
+					"%w: %s command requires old and new file arguments;"+
+						" got %v",
+					ErrUnsupportedOption,
+					CommandDiff,
+					c.InspectorSettings.CommandArgs,
+				)
+			}
+		}
+
+		if c.InspectorSettings.Command != CommandList && len(c.InspectorSettings.Pids) > 0 {
+			return fmt.Errorf(
+				"%w: %s command cannot be used with the %s flag",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Command,
+				PidFlagLong,
+			)
+		}
+
+		if c.InspectorSettings.Command != CommandList && c.InspectorSettings.Interactive {
+			return fmt.Errorf(
+				"%w: %s command cannot be used with the %s flag",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Command,
+				InteractiveFlagLong,
+			)
+		}
+
+		if c.InspectorSettings.Command != CommandList && c.InspectorSettings.ExplainStates {
+			return fmt.Errorf(
+				"%w: %s command cannot be used with the %s flag",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Command,
+				ExplainStatesFlagLong,
+			)
+		}
+
+		if c.InspectorSettings.Command == CommandCapture && len(c.Filters()) > 0 {
+			return fmt.Errorf(
+				"%w: %s command cannot be used with the %s, %s, %s, %s, %s or %s flags",
+				ErrUnsupportedOption,
+				CommandCapture,
+				StateFlagLong,
+				NameFlagLong,
+				NameRegexFlagLong,
+				UserFlagLong,
+				CgroupFlagLong,
+				PPidFlagLong,
+			)
+		}
+
+		if c.InspectorSettings.Command != CommandList && c.Archive != "" {
+			return fmt.Errorf(
+				"%w: %s command cannot be used with the %s flag",
+				ErrUnsupportedOption,
+				c.InspectorSettings.Command,
+				ArchiveFlagLong,
+			)
+		}
+
+		// Processes from a capture archive do not change, so there is
+		// nothing to refresh.
+		if c.Archive != "" && (c.InspectorSettings.Watch > 0 || c.InspectorSettings.Interactive) {
+			return fmt.Errorf(
+				"%w: %s and %s flags cannot be used with the %s flag",
+				ErrUnsupportedOption,
+				WatchFlagLong,
+				InteractiveFlagLong,
+				ArchiveFlagLong,
+			)
+		}
+
+	case appType.Plugin:
+		// The kernel log buffer of the host running the plugin is unrelated
+		// to processes from a capture archive (likely created elsewhere).
+		if c.HungTaskSource == procstate.KmsgSource && c.Archive != "" {
+			return fmt.Errorf(
+				"%w: %s flag cannot be set to %s with the %s flag;"+
+					" specify a dmesg or journal export file from the captured host instead",
+				ErrUnsupportedOption,
+				HungTaskSourceFlagLong,
+				procstate.KmsgSource,
+				ArchiveFlagLong,
+			)
+		}
+	}
 
 	// Optimist
 	return nil
@@ -1,10 +0,0 @@
-// Copyright 2022 Adam Chalkley
-//
-// https://github.com/atc0005/check-process
-//
-// Licensed under the MIT License. See LICENSE file in the project root for
-// full license information.
-
-// Package process provides functionality used to evaluate processes for
-// problematic states.
-package process
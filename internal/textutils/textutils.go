@@ -7,7 +7,13 @@
 
 package textutils
 
-import "strings"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // InList is a helper function to emulate Python's `if "x" in list:`
 // functionality. The caller can optionally ignore case of compared items.
@@ -29,3 +35,40 @@ func InList(needle string, haystack []string, ignoreCase bool) bool {
 	}
 	return false
 }
+
+// Printable returns the given value with control and other non-printable
+// characters replaced by Go escape sequences (e.g., "\x1b" or "\n") so that
+// the value can be safely written to a terminal. Invalid UTF-8 bytes are
+// replaced by "\xNN" escape sequences.
+func Printable(value string) string {
+	isPrintable := func(r rune) bool {
+		return r != utf8.RuneError && unicode.IsPrint(r)
+	}
+
+	// Most values do not require escaping.
+	if !strings.ContainsFunc(value, func(r rune) bool { return !isPrintable(r) }) {
+		return value
+	}
+
+	var b strings.Builder
+	b.Grow(len(value))
+
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&b, `\x%02x`, value[i])
+		case !isPrintable(r):
+			// Strip the quotes from the quoted (escaped) rune.
+			quoted := strconv.QuoteRune(r)
+			b.WriteString(quoted[1 : len(quoted)-1])
+		default:
+			b.WriteRune(r)
+		}
+
+		i += size
+	}
+
+	return b.String()
+}
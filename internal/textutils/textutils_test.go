@@ -0,0 +1,43 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package textutils
+
+import "testing"
+
+// TestPrintable asserts that control and non-printable characters are
+// escaped while printable (including multi-byte) characters are retained.
+func TestPrintable(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"empty":                  {value: "", want: ""},
+		"printable":              {value: "/usr/bin/java -Xmx2g", want: "/usr/bin/java -Xmx2g"},
+		"multi-byte":             {value: "Web Content – café", want: "Web Content – café"},
+		"CSI sequence":           {value: "sleep \x1b[2J\x1b[31m", want: `sleep \x1b[2J\x1b[31m`},
+		"OSC sequence":           {value: "\x1b]0;pwned\x07", want: `\x1b]0;pwned\a`},
+		"C1 control":             {value: "a\u009bb", want: `a\u009bb`},
+		"newline and tab":        {value: "a\nb\tc\r", want: `a\nb\tc\r`},
+		"nul byte":               {value: "a\x00b", want: `a\x00b`},
+		"delete":                 {value: "a\x7fb", want: `a\x7fb`},
+		"invalid UTF-8":          {value: "a\xffb\xc3", want: `a\xffb\xc3`},
+		"bidirectional override": {value: "a\u202eb", want: `a\u202eb`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Printable(tt.value); got != tt.want {
+				t.Errorf("\nwant %q\ngot  %q", tt.want, got)
+			}
+		})
+	}
+}
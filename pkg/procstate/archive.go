@@ -0,0 +1,371 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveProcRootDir is the directory within a capture archive holding the
+// captured proc filesystem content. Paths within this directory mirror
+// those of the proc filesystem.
+const ArchiveProcRootDir string = "proc"
+
+// Limits applied when extracting a capture archive. These guard against
+// malformed or malicious archives exhausting available disk space.
+const (
+	// maxArchiveFileSize is the maximum size of a single file extracted
+	// from a capture archive.
+	maxArchiveFileSize int64 = 16 << 20
+
+	// maxArchiveTotalSize is the maximum combined size of all files
+	// extracted from a capture archive.
+	maxArchiveTotalSize int64 = 1 << 30
+
+	// maxArchiveEntries is the maximum number of entries extracted from a
+	// capture archive.
+	maxArchiveEntries int = 1_000_000
+)
+
+// archiveLimits is the set of limits applied when extracting a capture
+// archive.
+type archiveLimits struct {
+	fileSize  int64
+	totalSize int64
+	entries   int
+}
+
+// defaultArchiveLimits is the set of limits applied by ExtractArchive.
+var defaultArchiveLimits = archiveLimits{
+	fileSize:  maxArchiveFileSize,
+	totalSize: maxArchiveTotalSize,
+	entries:   maxArchiveEntries,
+}
+
+// archiveProcessFiles is the list of files captured from each /proc/[pid]
+// directory. Files which cannot be read (e.g., stack without sufficient
+// privileges) are skipped.
+var archiveProcessFiles = []string{
+	ProcStatusFilename,
+	ProcStatFilename,
+	ProcCmdlineFilename,
+	ProcWchanFilename,
+	ProcStackFilename,
+	ProcCgroupFilename,
+	ProcIOFilename,
+	ProcLimitsFilename,
+}
+
+// archiveSystemFiles is the list of files (relative to the proc filesystem)
+// captured for the system as a whole. Files which cannot be read are
+// skipped.
+var archiveSystemFiles = []string{
+	ProcSystemStatFilename,
+	ProcLoadAvgFilename,
+	ProcMountInfoFilename,
+	ProcKernelReleaseFilename,
+	ProcKernelHostnameFilename,
+	ProcHungTaskTimeoutFilename,
+	ProcHungTaskWarningsFilename,
+}
+
+// ArchiveProcessFiles returns the list of files captured from each
+// /proc/[pid] directory by CaptureArchive.
+func ArchiveProcessFiles() []string {
+	return slices.Clone(archiveProcessFiles)
+}
+
+// ArchiveSystemFiles returns the list of files (relative to the proc
+// filesystem) captured for the system as a whole by CaptureArchive.
+func ArchiveSystemFiles() []string {
+	return slices.Clone(archiveSystemFiles)
+}
+
+// CaptureArchive copies the system files and the files of each /proc/[pid]
+// directory within the specified proc filesystem path (usually "/proc") to
+// the given io.Writer as a gzip compressed tar archive. Files are stored
+// within the ArchiveProcRootDir directory of the archive using their path
+// relative to the proc filesystem. Processes with the specified (optional)
+// process ID values are not captured.
+//
+// Files which cannot be read (e.g., due to insufficient privileges or
+// because a process exited during the capture) are skipped. An error is
+// returned if the proc filesystem cannot be listed or if the archive cannot
+// be written.
+func CaptureArchive(w io.Writer, procRoot string, excludePids ...int) error {
+	procDirs, err := GetProcDirs(procRoot)
+	if err != nil {
+		return err
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	modTime := time.Now()
+
+	capture := func(relPath string) error {
+		data, err := os.ReadFile(filepath.Join(procRoot, filepath.FromSlash(relPath)))
+		if err != nil {
+			// Unreadable files are expected (e.g., restricted files or
+			// processes which have exited) and are not recorded.
+			return nil
+		}
+
+		hdr := tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(ArchiveProcRootDir, relPath),
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			ModTime:  modTime,
+		}
+
+		if err := tw.WriteHeader(&hdr); err != nil {
+			return fmt.Errorf("failed to write archive header for %s: %w", relPath, err)
+		}
+
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write archive content for %s: %w", relPath, err)
+		}
+
+		return nil
+	}
+
+	for _, relPath := range archiveSystemFiles {
+		if err := capture(relPath); err != nil {
+			return err
+		}
+	}
+
+	for _, procDir := range procDirs {
+		if pid, err := strconv.Atoi(procDir); err == nil && slices.Contains(excludePids, pid) {
+			continue
+		}
+
+		for _, filename := range archiveProcessFiles {
+			if err := capture(path.Join(procDir, filename)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	if err := gzw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize archive compression: %w", err)
+	}
+
+	return nil
+}
+
+// CaptureArchiveFile captures the specified proc filesystem path to the
+// given file using CaptureArchive. The file is created if it does not
+// exist, replaced if it does and is removed if the capture fails.
+func CaptureArchiveFile(filename string, procRoot string, excludePids ...int) error {
+	f, err := os.OpenFile(filepath.Clean(filename), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive file %s: %w", filename, err)
+	}
+
+	captureErr := CaptureArchive(f, procRoot, excludePids...)
+	closeErr := f.Close()
+
+	if err := errors.Join(captureErr, closeErr); err != nil {
+		_ = os.Remove(filename)
+
+		return fmt.Errorf("failed to capture archive file %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// ExtractArchive extracts a capture archive created by CaptureArchive from
+// the given io.Reader into the specified existing directory and returns the
+// path to the extracted proc filesystem content. This path is suitable for
+// use with WithProcRoot.
+//
+// Only regular files and directories are extracted. An error wrapping
+// ErrInvalidArchive is returned for archives with unsupported entry types
+// (e.g., symbolic links), entries with paths outside of the destination
+// directory or content exceeding size limits.
+func ExtractArchive(r io.Reader, dir string) (string, error) {
+	return extractArchive(r, dir, defaultArchiveLimits)
+}
+
+// extractArchive implements ExtractArchive using the specified limits.
+func extractArchive(r io.Reader, dir string, limits archiveLimits) (string, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress archive: %w: %w", ErrInvalidArchive, err)
+	}
+	defer func() {
+		_ = gzr.Close()
+	}()
+
+	tr := tar.NewReader(gzr)
+
+	var totalSize int64
+	var entries int
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read archive: %w: %w", ErrInvalidArchive, err)
+		}
+
+		entries++
+		if entries > limits.entries {
+			return "", fmt.Errorf(
+				"archive exceeds limit of %d entries: %w",
+				limits.entries,
+				ErrInvalidArchive,
+			)
+		}
+
+		target, err := archiveEntryPath(dir, hdr.Name)
+		if err != nil {
+			return "", err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o700); err != nil {
+				return "", fmt.Errorf("failed to create directory %s: %w", target, err)
+			}
+
+		case tar.TypeReg:
+			if hdr.Size < 0 || hdr.Size > limits.fileSize {
+				return "", fmt.Errorf(
+					"archive entry %q size %d exceeds limit of %d bytes: %w",
+					hdr.Name,
+					hdr.Size,
+					limits.fileSize,
+					ErrInvalidArchive,
+				)
+			}
+
+			totalSize += hdr.Size
+			if totalSize > limits.totalSize {
+				return "", fmt.Errorf(
+					"archive content exceeds limit of %d bytes: %w",
+					limits.totalSize,
+					ErrInvalidArchive,
+				)
+			}
+
+			if err := extractArchiveFile(tr, target, hdr.Size); err != nil {
+				return "", err
+			}
+
+		default:
+			return "", fmt.Errorf(
+				"archive entry %q has unsupported type %q: %w",
+				hdr.Name,
+				hdr.Typeflag,
+				ErrInvalidArchive,
+			)
+		}
+	}
+
+	procRoot := filepath.Join(dir, ArchiveProcRootDir)
+	if fi, err := os.Stat(procRoot); err != nil || !fi.IsDir() {
+		return "", fmt.Errorf(
+			"archive does not contain %s directory: %w",
+			ArchiveProcRootDir,
+			ErrInvalidArchive,
+		)
+	}
+
+	return procRoot, nil
+}
+
+// ExtractArchiveFile extracts the specified capture archive file into a new
+// temporary directory using ExtractArchive. The path to the extracted proc
+// filesystem content is returned along with a cleanup function which
+// removes the temporary directory. The cleanup function should be called
+// once the extracted content is no longer needed.
+func ExtractArchiveFile(filename string) (string, func(), error) {
+	f, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open archive file %s: %w", filename, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	dir, err := os.MkdirTemp("", "procstate-archive-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	procRoot, err := ExtractArchive(f, dir)
+	if err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("failed to extract archive file %s: %w", filename, err)
+	}
+
+	return procRoot, cleanup, nil
+}
+
+// archiveEntryPath returns the destination path within the specified
+// directory for the given archive entry name. An error is returned if the
+// entry name is absolute or refers to a location outside of the directory.
+func archiveEntryPath(dir string, name string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+
+	if cleaned == "." || !filepath.IsLocal(filepath.FromSlash(cleaned)) {
+		return "", fmt.Errorf(
+			"archive entry %q has invalid path: %w",
+			name,
+			ErrInvalidArchive,
+		)
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(cleaned)), nil
+}
+
+// extractArchiveFile copies size bytes from the given io.Reader to a new
+// file at the specified path, creating parent directories as needed.
+func extractArchiveFile(r io.Reader, target string, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", target, err)
+	}
+
+	_, copyErr := io.Copy(f, io.LimitReader(r, size))
+	closeErr := f.Close()
+
+	if err := errors.Join(copyErr, closeErr); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", target, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testArchiveEntry is an entry of a capture archive built by
+// newTestArchive. The content of regular files is written unless
+// headerOnly is set, in which case the archive ends after the header.
+type testArchiveEntry struct {
+	hdr        tar.Header
+	content    string
+	headerOnly bool
+}
+
+// testArchiveFile returns a regular file testArchiveEntry with the given
+// name and content.
+func testArchiveFile(name string, content string) testArchiveEntry {
+	return testArchiveEntry{
+		hdr: tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+		},
+		content: content,
+	}
+}
+
+// newTestArchive builds a gzip compressed tar archive in memory from the
+// given entries.
+func newTestArchive(t *testing.T, entries ...testArchiveEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	complete := true
+	for _, entry := range entries {
+		if err := tw.WriteHeader(&entry.hdr); err != nil {
+			t.Fatalf("failed to write archive header for %s: %v", entry.hdr.Name, err)
+		}
+
+		if entry.headerOnly {
+			complete = false
+
+			break
+		}
+
+		if _, err := tw.Write([]byte(entry.content)); err != nil {
+			t.Fatalf("failed to write archive content for %s: %v", entry.hdr.Name, err)
+		}
+	}
+
+	if complete {
+		if err := tw.Close(); err != nil {
+			t.Fatalf("failed to finalize archive: %v", err)
+		}
+	}
+
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to finalize archive compression: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// TestExtractArchiveRejected asserts that archives with unsafe entries or
+// content exceeding the extraction limits are rejected and that nothing is
+// written outside of the destination directory.
+func TestExtractArchiveRejected(t *testing.T) {
+	t.Parallel()
+
+	status := testArchiveFile("proc/1/status", "Name:\tsystemd\nState:\tS (sleeping)\n")
+
+	special := func(typeflag byte, name string, linkname string) testArchiveEntry {
+		return testArchiveEntry{
+			hdr: tar.Header{
+				Typeflag: typeflag,
+				Name:     name,
+				Linkname: linkname,
+				Mode:     0o644,
+			},
+		}
+	}
+
+	smallLimits := archiveLimits{fileSize: 64, totalSize: 100, entries: 4}
+
+	tests := map[string]struct {
+		entries []testArchiveEntry
+		limits  archiveLimits
+		wantErr error
+	}{
+		"parent directory path": {
+			entries: []testArchiveEntry{status, testArchiveFile("../escaped", "x")},
+			wantErr: ErrInvalidArchive,
+		},
+		"nested parent directory path": {
+			entries: []testArchiveEntry{status, testArchiveFile("proc/../../escaped", "x")},
+			wantErr: ErrInvalidArchive,
+		},
+		"backslash parent directory path": {
+			entries: []testArchiveEntry{status, testArchiveFile(`proc\..\..\escaped`, "x")},
+			wantErr: ErrInvalidArchive,
+		},
+		"absolute path": {
+			entries: []testArchiveEntry{status, testArchiveFile("/tmp/escaped", "x")},
+			wantErr: ErrInvalidArchive,
+		},
+		"archive root path": {
+			entries: []testArchiveEntry{special(tar.TypeDir, "./", "")},
+			wantErr: ErrInvalidArchive,
+		},
+		"symbolic link": {
+			entries: []testArchiveEntry{special(tar.TypeSymlink, "proc/1/stat", "/etc/passwd"), status},
+			wantErr: ErrInvalidArchive,
+		},
+		"hard link": {
+			entries: []testArchiveEntry{status, special(tar.TypeLink, "proc/1/stat", "proc/1/status")},
+			wantErr: ErrInvalidArchive,
+		},
+		"character device": {
+			entries: []testArchiveEntry{special(tar.TypeChar, "proc/1/stat", ""), status},
+			wantErr: ErrInvalidArchive,
+		},
+		"block device": {
+			entries: []testArchiveEntry{special(tar.TypeBlock, "proc/1/stat", ""), status},
+			wantErr: ErrInvalidArchive,
+		},
+		"fifo": {
+			entries: []testArchiveEntry{special(tar.TypeFifo, "proc/1/stat", ""), status},
+			wantErr: ErrInvalidArchive,
+		},
+		"file size exceeds default limit": {
+			entries: []testArchiveEntry{
+				status,
+				{
+					hdr: tar.Header{
+						Typeflag: tar.TypeReg,
+						Name:     "proc/1/stack",
+						Mode:     0o644,
+						Size:     maxArchiveFileSize + 1,
+					},
+					headerOnly: true,
+				},
+			},
+			wantErr: ErrInvalidArchive,
+		},
+		"file size exceeds limit": {
+			entries: []testArchiveEntry{testArchiveFile("proc/1/stack", strings.Repeat("x", 65))},
+			limits:  smallLimits,
+			wantErr: ErrInvalidArchive,
+		},
+		"total size exceeds limit": {
+			entries: []testArchiveEntry{
+				testArchiveFile("proc/1/stack", strings.Repeat("x", 50)),
+				testArchiveFile("proc/2/stack", strings.Repeat("x", 50)),
+				testArchiveFile("proc/3/stack", "x"),
+			},
+			limits:  smallLimits,
+			wantErr: ErrInvalidArchive,
+		},
+		"entry count exceeds limit": {
+			entries: []testArchiveEntry{
+				special(tar.TypeDir, "proc/", ""),
+				testArchiveFile("proc/1/cmdline", "a"),
+				testArchiveFile("proc/2/cmdline", "b"),
+				testArchiveFile("proc/3/cmdline", "c"),
+				testArchiveFile("proc/4/cmdline", "d"),
+			},
+			limits:  smallLimits,
+			wantErr: ErrInvalidArchive,
+		},
+		"duplicate entry": {
+			entries: []testArchiveEntry{status, testArchiveFile("proc/1/status", "Name:\tevil\n")},
+			wantErr: fs.ErrExist,
+		},
+		"duplicate entry with different path": {
+			entries: []testArchiveEntry{status, testArchiveFile("proc/2/../1/status", "Name:\tevil\n")},
+			wantErr: fs.ErrExist,
+		},
+		"missing proc directory": {
+			entries: []testArchiveEntry{testArchiveFile("other/1/status", "Name:\tsystemd\n")},
+			wantErr: ErrInvalidArchive,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			limits := tt.limits
+			if limits == (archiveLimits{}) {
+				limits = defaultArchiveLimits
+			}
+
+			// The destination directory is nested so that escaped entries
+			// would be written to the parent directory.
+			parent := t.TempDir()
+			dir := filepath.Join(parent, "extract")
+			if err := os.Mkdir(dir, 0o700); err != nil {
+				t.Fatalf("failed to create destination directory: %v", err)
+			}
+
+			archive := newTestArchive(t, tt.entries...)
+
+			procRoot, err := extractArchive(bytes.NewReader(archive), dir, limits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error wrapping %v, got %v (proc root %q)", tt.wantErr, err, procRoot)
+			}
+
+			if _, err := os.Lstat(filepath.Join(parent, "escaped")); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("archive entry written outside of destination directory")
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, "proc", "1", "status"))
+			if err == nil && string(data) != status.content {
+				t.Errorf("existing file replaced: got content %q", data)
+			}
+
+			if _, err := os.Lstat(filepath.Join(dir, "proc", "1", "stat")); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("unsupported archive entry extracted")
+			}
+		})
+	}
+}
+
+// TestExtractArchiveDefaultLimits asserts that the default limits apply to
+// exported extraction.
+func TestExtractArchiveDefaultLimits(t *testing.T) {
+	t.Parallel()
+
+	archive := newTestArchive(t, testArchiveEntry{
+		hdr: tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     "proc/1/stack",
+			Mode:     0o644,
+			Size:     maxArchiveFileSize + 1,
+		},
+		headerOnly: true,
+	})
+
+	if _, err := ExtractArchive(bytes.NewReader(archive), t.TempDir()); !errors.Is(err, ErrInvalidArchive) {
+		t.Errorf("want error wrapping %v, got %v", ErrInvalidArchive, err)
+	}
+
+	if _, err := ExtractArchive(strings.NewReader("not an archive"), t.TempDir()); !errors.Is(err, ErrInvalidArchive) {
+		t.Errorf("want error wrapping %v for content which is not an archive, got %v", ErrInvalidArchive, err)
+	}
+}
+
+// TestCaptureArchiveRoundTrip asserts that processes collected from an
+// extracted capture archive match those collected from the captured proc
+// filesystem and that excluded processes are not captured.
+func TestCaptureArchiveRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	procRoot := newTestProcRoot(t)
+
+	var buf bytes.Buffer
+	if err := CaptureArchive(&buf, procRoot, 7702); err != nil {
+		t.Fatalf("failed to capture archive: %v", err)
+	}
+
+	extracted, err := ExtractArchive(&buf, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to extract archive: %v", err)
+	}
+
+	collect := func(root string) Processes {
+		collector, err := NewCollector(WithProcRoot(root), WithAllProperties())
+		if err != nil {
+			t.Fatalf("failed to create collector: %v", err)
+		}
+
+		processes, err := collector.Collect()
+		if err != nil {
+			t.Fatalf("failed to collect processes: %v", err)
+		}
+
+		// The proc directory differs between the captured and extracted
+		// proc filesystems.
+		for i := range processes {
+			processes[i].ProcDir = ""
+		}
+
+		return processes
+	}
+
+	want := collect(procRoot).Filter(Not(ByPid(7702)))
+	got := collect(extracted)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, got)
+	}
+
+	if !slices.Equal(pids(got), []int{1187, 31337, 389, 4321, 61012}) {
+		t.Errorf("unexpected processes extracted: %v", pids(got))
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultConcurrency is the default number of /proc/[pid] directories
+// evaluated concurrently by a Collector.
+const DefaultConcurrency int = 1
+
+// Collector gathers Process values from a proc filesystem.
+type Collector struct {
+	procRoot      string
+	concurrency   int
+	filters       []Predicate
+	allProperties bool
+}
+
+// Option is a functional option used to configure a Collector.
+type Option func(*Collector) error
+
+// WithProcRoot specifies the base path of the proc filesystem used by the
+// Collector. This is useful when evaluating a proc filesystem mounted at a
+// non-default location (e.g., from within a container) or a copy of one.
+// The default is "/proc".
+func WithProcRoot(path string) Option {
+	return func(c *Collector) error {
+		if path == "" {
+			return fmt.Errorf(
+				"empty proc root path specified: %w",
+				ErrInvalidCollectorOption,
+			)
+		}
+
+		c.procRoot = path
+
+		return nil
+	}
+}
+
+// WithConcurrency specifies the number of /proc/[pid] directories evaluated
+// concurrently by the Collector. The order of collected Process values is
+// not affected by this setting.
+func WithConcurrency(n int) Option {
+	return func(c *Collector) error {
+		if n < 1 {
+			return fmt.Errorf(
+				"concurrency value %d is less than 1: %w",
+				n,
+				ErrInvalidCollectorOption,
+			)
+		}
+
+		c.concurrency = n
+
+		return nil
+	}
+}
+
+// WithFilter specifies predicate functions used to filter collected Process
+// values. Only Process values matching all predicates are retained. This
+// option may be specified multiple times.
+func WithFilter(preds ...Predicate) Option {
+	return func(c *Collector) error {
+		for _, pred := range preds {
+			if pred == nil {
+				return fmt.Errorf(
+					"nil filter specified: %w",
+					ErrInvalidCollectorOption,
+				)
+			}
+		}
+
+		c.filters = append(c.filters, preds...)
+
+		return nil
+	}
+}
+
+// WithAllProperties specifies that all /proc/[pid]/status properties are
+// retained in the AllProperties index of collected Process values. By
+// default only the properties listed by EvaluatedProperties are retained as
+// parsing is significantly faster and allocates far less memory.
+func WithAllProperties() Option {
+	return func(c *Collector) error {
+		c.allProperties = true
+
+		return nil
+	}
+}
+
+// NewCollector creates a new Collector using the specified options. An
+// error is returned if any option is invalid.
+func NewCollector(opts ...Option) (*Collector, error) {
+	c := Collector{
+		procRoot:    ProcRootDir,
+		concurrency: DefaultConcurrency,
+	}
+
+	for _, opt := range opts {
+		if err := opt(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
+
+// ProcRoot returns the base path of the proc filesystem used by the
+// Collector.
+func (c *Collector) ProcRoot() string {
+	return c.procRoot
+}
+
+// Collect evaluates each /proc/[pid] directory within the proc filesystem
+// and returns a collection of Process values matching all configured
+// filters. Processes which exit during evaluation are skipped. Process
+// values are returned in the order their directories were listed. The
+// first error encountered evaluating a process is returned.
+func (c *Collector) Collect() (Processes, error) {
+	processes, procErrs, err := c.collect()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(procErrs) > 0 {
+		return nil, procErrs[0].err
+	}
+
+	return processes, nil
+}
+
+// procDirError records an error encountered evaluating a specific
+// /proc/[pid] directory.
+type procDirError struct {
+	procDir string
+	err     error
+}
+
+// collect evaluates each /proc/[pid] directory within the proc filesystem
+// and returns a collection of Process values matching all configured
+// filters along with any errors encountered evaluating specific processes.
+// An error is returned if the proc filesystem cannot be listed.
+func (c *Collector) collect() (Processes, []procDirError, error) {
+	procDirs, err := GetProcDirs(c.procRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	type result struct {
+		process Process
+		ok      bool
+		err     error
+	}
+
+	results := make([]result, len(procDirs))
+
+	workers := min(c.concurrency, len(procDirs))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				p, ok, err := processFromProcDir(filepath.Join(c.procRoot, procDirs[i]), c.allProperties)
+				results[i] = result{process: p, ok: ok, err: err}
+			}
+		}()
+	}
+
+	for i := range procDirs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	filter := And(c.filters...)
+
+	processes := make(Processes, 0, len(procDirs))
+	var procErrs []procDirError
+	for i, r := range results {
+		if r.err != nil {
+			procErrs = append(procErrs, procDirError{procDir: procDirs[i], err: r.err})
+			continue
+		}
+
+		if r.ok && filter(r.process) {
+			processes = append(processes, r.process)
+		}
+	}
+
+	return processes, procErrs, nil
+}
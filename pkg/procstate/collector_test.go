@@ -0,0 +1,222 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// testProcFixtures is the index of process ID to the base name of the
+// status and stat testdata files used for that process.
+var testProcFixtures = map[int]struct {
+	status string
+	stat   string
+}{
+	389:   {status: "rhel7-kthread", stat: "rhel7-kthread"},
+	1187:  {status: "rhel6-kthread-colon", stat: "rhel6-kthread-colon"},
+	4321:  {status: "rhel6-disk-sleep", stat: "rhel6-disk-sleep"},
+	7702:  {status: "rhel9-name-with-space", stat: "rhel9-name-with-space"},
+	31337: {status: "rhel9-zombie-escaped-newline", stat: "rhel9-zombie-paren"},
+	61012: {status: "rhel8-idle-kthread", stat: "rhel8-idle-kthread"},
+}
+
+// newTestProcRoot creates a proc filesystem from the status and stat
+// testdata files and returns the path to it. Entries which are not process
+// directories are included in order to assert that they are skipped.
+func newTestProcRoot(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	copyFile := func(src string, dst string) {
+		data, err := os.ReadFile(filepath.Clean(src))
+		if err != nil {
+			t.Fatalf("failed to read testdata file: %v", err)
+		}
+
+		if err := os.WriteFile(dst, data, 0o600); err != nil {
+			t.Fatalf("failed to create proc file: %v", err)
+		}
+	}
+
+	for pid, fixture := range testProcFixtures {
+		procDir := filepath.Join(root, strconv.Itoa(pid))
+		if err := os.Mkdir(procDir, 0o700); err != nil {
+			t.Fatalf("failed to create proc directory: %v", err)
+		}
+
+		copyFile(filepath.Join("testdata", "status", fixture.status+".status"), filepath.Join(procDir, ProcStatusFilename))
+		copyFile(filepath.Join("testdata", "stat", fixture.stat+".stat"), filepath.Join(procDir, ProcStatFilename))
+	}
+
+	// A process which exited after its directory was listed.
+	if err := os.Mkdir(filepath.Join(root, "9999"), 0o700); err != nil {
+		t.Fatalf("failed to create proc directory: %v", err)
+	}
+
+	for _, name := range []string{"sys", "self"} {
+		if err := os.Mkdir(filepath.Join(root, name), 0o700); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+	}
+
+	return root
+}
+
+// collectTestProcesses collects processes from a proc filesystem created
+// from testdata files using the specified options.
+func collectTestProcesses(t *testing.T, opts ...Option) Processes {
+	t.Helper()
+
+	opts = append([]Option{WithProcRoot(newTestProcRoot(t))}, opts...)
+
+	collector, err := NewCollector(opts...)
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	processes, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("failed to collect processes: %v", err)
+	}
+
+	return processes
+}
+
+// TestCollectorWithProcRoot asserts that processes are collected from the
+// specified proc filesystem in directory listing order and that values
+// from both the status and stat files are set.
+func TestCollectorWithProcRoot(t *testing.T) {
+	t.Parallel()
+
+	processes := collectTestProcesses(t)
+
+	want := []int{1187, 31337, 389, 4321, 61012, 7702}
+	if got := pids(processes); !slices.Equal(got, want) {
+		t.Fatalf("\nwant pids %v\ngot pids  %v", want, got)
+	}
+
+	java := processes.Filter(ByPid(4321))[0]
+	if java.Name != "java" ||
+		java.State.String() != KernelAnyProcessStateDiskSleep.String() ||
+		java.PPid != 1 ||
+		java.Threads != 87 ||
+		java.VMSwapKB() != 10240 ||
+		java.StartTime == 0 {
+		t.Errorf("unexpected values for process 4321: %+v", java)
+	}
+
+	if !processes.Filter(ByPid(1187))[0].IsKernelThread() {
+		t.Errorf("want process 1187 identified as a kernel thread")
+	}
+
+	for _, p := range processes {
+		if filepath.Dir(p.ProcDir) == ProcRootDir {
+			t.Errorf("process %d proc directory %s is not within the specified proc root", p.Pid, p.ProcDir)
+		}
+	}
+}
+
+// TestCollectorWithFilter asserts that only processes matching all
+// specified filters are collected.
+func TestCollectorWithFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts []Option
+		want []int
+	}{
+		"single filter": {
+			opts: []Option{WithFilter(Not(IsKernelThread))},
+			want: []int{31337, 4321, 7702},
+		},
+		"filters in one option are combined": {
+			opts: []Option{WithFilter(Not(IsKernelThread), ByStateCode('S'))},
+			want: []int{7702},
+		},
+		"filters across options are combined": {
+			opts: []Option{
+				WithFilter(IsKernelThread),
+				WithFilter(ByStateCode('I', 'S')),
+				WithFilter(Not(ByPid(389))),
+			},
+			want: []int{1187, 61012},
+		},
+		"no matches": {
+			opts: []Option{WithFilter(ByName("missing"))},
+			want: []int{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := pids(collectTestProcesses(t, tt.opts...))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant pids %v\ngot pids  %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestCollectorWithAllProperties asserts that all status file properties
+// are only retained if requested.
+func TestCollectorWithAllProperties(t *testing.T) {
+	t.Parallel()
+
+	selective := collectTestProcesses(t).Filter(ByPid(4321))[0]
+	if _, ok := selective.AllProperties[ProcessSigCgtField]; ok {
+		t.Errorf("property %s retained without requesting all properties", ProcessSigCgtField)
+	}
+
+	for _, property := range EvaluatedProperties() {
+		if _, ok := selective.AllProperties[property]; !ok {
+			t.Errorf("evaluated property %s not retained", property)
+		}
+	}
+
+	all := collectTestProcesses(t, WithAllProperties()).Filter(ByPid(4321))[0]
+	if got := all.AllProperties[ProcessSigCgtField]; got != "2000000181005ccd" {
+		t.Errorf("want property %s value %q, got %q", ProcessSigCgtField, "2000000181005ccd", got)
+	}
+
+	if len(all.AllProperties) <= len(selective.AllProperties) {
+		t.Errorf(
+			"want more than %d properties when requesting all properties, got %d",
+			len(selective.AllProperties),
+			len(all.AllProperties),
+		)
+	}
+}
+
+// TestNewCollectorInvalidOptions asserts that invalid options are rejected.
+func TestNewCollectorInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Option{
+		"empty proc root":  WithProcRoot(""),
+		"nil filter":       WithFilter(ByPid(1), nil),
+		"zero concurrency": WithConcurrency(0),
+	}
+
+	for name, opt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewCollector(opt); !errors.Is(err, ErrInvalidCollectorOption) {
+				t.Errorf("want error wrapping %v, got %v", ErrInvalidCollectorOption, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+const (
+
+	// ProcRootDir is the default mount point for the proc virtual filesystem.
+	ProcRootDir string = "/proc"
+
+	// ProcStatusFilename is the name of the human-readable process status
+	// information present for each process directory in the proc filesystem.
+	ProcStatusFilename string = "status"
+
+	// ProcStatFilename is the name of the machine-readable process status
+	// information present for each process directory in the proc filesystem.
+	ProcStatFilename string = "stat"
+
+	// ProcCmdlineFilename is the name of the file containing the complete
+	// command line for a process present for each process directory in the
+	// proc filesystem.
+	ProcCmdlineFilename string = "cmdline"
+
+	// ProcCgroupFilename is the name of the file describing the control
+	// groups to which a process belongs present for each process directory
+	// in the proc filesystem.
+	ProcCgroupFilename string = "cgroup"
+
+	// ProcWchanFilename is the name of the file containing the name of the
+	// kernel function in which a process is sleeping present for each
+	// process directory in the proc filesystem.
+	ProcWchanFilename string = "wchan"
+
+	// ProcStackFilename is the name of the file containing the kernel stack
+	// trace of a process present for each process directory in the proc
+	// filesystem.
+	ProcStackFilename string = "stack"
+
+	// ProcLimitsFilename is the name of the file containing the resource
+	// limits of a process present for each process directory in the proc
+	// filesystem.
+	ProcLimitsFilename string = "limits"
+
+	// ProcIOFilename is the name of the file containing the I/O statistics
+	// of a process present for each process directory in the proc
+	// filesystem.
+	ProcIOFilename string = "io"
+
+	// ProcFDDirname is the name of the directory containing an entry for
+	// each open file descriptor of a process present for each process
+	// directory in the proc filesystem.
+	ProcFDDirname string = "fd"
+
+	// ProcDirRegex is the regex pattern used to match process directory names
+	// within the proc virtual filesystem.
+	ProcDirRegex string = "^[0-9]+$"
+
+	// ProcKernelReleaseFilename is the path (relative to the proc
+	// filesystem mount point) of the running kernel's release value.
+	ProcKernelReleaseFilename string = "sys/kernel/osrelease"
+
+	// ProcHungTaskTimeoutFilename is the path (relative to the proc
+	// filesystem mount point) of the kernel hung task detector timeout
+	// setting.
+	ProcHungTaskTimeoutFilename string = "sys/kernel/hung_task_timeout_secs"
+
+	// ProcHungTaskWarningsFilename is the path (relative to the proc
+	// filesystem mount point) of the kernel hung task detector remaining
+	// warnings setting.
+	ProcHungTaskWarningsFilename string = "sys/kernel/hung_task_warnings"
+
+	// ProcKernelHostnameFilename is the path (relative to the proc
+	// filesystem mount point) of the system hostname.
+	ProcKernelHostnameFilename string = "sys/kernel/hostname"
+
+	// ProcSystemStatFilename is the name of the file (relative to the proc
+	// filesystem mount point) containing kernel and system statistics,
+	// including the system boot time.
+	ProcSystemStatFilename string = "stat"
+
+	// ProcLoadAvgFilename is the name of the file (relative to the proc
+	// filesystem mount point) containing the system load averages.
+	ProcLoadAvgFilename string = "loadavg"
+
+	// ProcMountInfoFilename is the path (relative to the proc filesystem
+	// mount point) of the mount details for the current process.
+	ProcMountInfoFilename string = "self/mountinfo"
+)
+
+const (
+	// KmsgDevice is the character device exposing the kernel log buffer.
+	KmsgDevice string = "/dev/kmsg"
+
+	// KmsgSource is the keyword used to indicate that the kernel log buffer
+	// device should be used as the source of kernel log messages.
+	KmsgSource string = "kmsg"
+
+	// kmsgRecordMaxSize is the buffer size used when reading a single record
+	// from the kernel log buffer device. Reads using a smaller buffer than
+	// the record size fail with EINVAL.
+	kmsgRecordMaxSize int = 8192
+)
+
+// ClockTicksPerSecond is the number of clock ticks per second (USER_HZ)
+// used by the kernel for time values in /proc/[pid]/stat files. While
+// sysconf(_SC_CLK_TCK) is the authoritative source, this value is fixed at
+// 100 for all supported architectures.
+const ClockTicksPerSecond uint64 = 100
+
+// KThreaddPID is the process ID of the kernel thread daemon, the parent of
+// all kernel threads.
+const KThreaddPID int = 2
+
+// PFKThread is the kernel flags word bit (PF_KTHREAD) set for kernel
+// threads.
+//
+// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/include/linux/sched.h
+const PFKThread uint = 0x00200000
+
+// Process status field names.
+const (
+	ProcessNameField    string = "name"
+	ProcessStateField   string = "state"
+	ProcessPidField     string = "pid"
+	ProcessPPidField    string = "ppid"
+	ProcessThreadsField string = "threads"
+	ProcessVMSwapField  string = "vmswap"
+	ProcessVMRSSField   string = "vmrss"
+	ProcessUIDField     string = "uid"
+)
+
+// Process status field names for signal masks. Each value is a hexadecimal
+// bitmask where bit N-1 represents signal N.
+const (
+	ProcessSigPndField string = "sigpnd" // pending for the thread
+	ProcessShdPndField string = "shdpnd" // pending for the process
+	ProcessSigBlkField string = "sigblk" // blocked
+	ProcessSigIgnField string = "sigign" // ignored
+	ProcessSigCgtField string = "sigcgt" // caught
+)
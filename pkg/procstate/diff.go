@@ -0,0 +1,212 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"sort"
+)
+
+// Process fields compared when generating a Diff.
+const (
+	DiffFieldState   string = "state"
+	DiffFieldPPid    string = "ppid"
+	DiffFieldThreads string = "threads"
+	DiffFieldVMRSS   string = "vmrss"
+	DiffFieldVMSwap  string = "vmswap"
+)
+
+// DiffOptions is the collection of settings used when generating a Diff.
+type DiffOptions struct {
+	// MemoryThresholdKB is the minimum change in resident set size or swap
+	// memory usage (in kilobytes) for a process to be considered changed.
+	// Any change is reported if this value is zero.
+	MemoryThresholdKB int
+}
+
+// ProcessChange records a process present in both collections of a Diff
+// with one or more changed fields.
+type ProcessChange struct {
+	// Old is the process as found in the old collection.
+	Old Process `json:"old"`
+
+	// New is the process as found in the new collection.
+	New Process `json:"new"`
+
+	// Fields is the list of changed fields (e.g., DiffFieldState).
+	Fields []string `json:"fields"`
+}
+
+// StateDelta records the number of processes found in a process state for
+// each collection of a Diff.
+type StateDelta struct {
+	// State is the process state in the same format used by the
+	// /proc/[pid]/status file (e.g., "S (sleeping)").
+	State string `json:"state"`
+
+	// Old is the number of processes in the state in the old collection.
+	Old int `json:"old"`
+
+	// New is the number of processes in the state in the new collection.
+	New int `json:"new"`
+}
+
+// Delta returns the change in the number of processes found in the state.
+func (d StateDelta) Delta() int {
+	return d.New - d.Old
+}
+
+// Diff is the difference between two collections of processes. Processes
+// are matched by ProcessKey (process ID and start time) so that a process
+// reusing the ID of an exited process is reported as a new process.
+type Diff struct {
+	// Appeared is the collection of processes found only in the new
+	// collection.
+	Appeared Processes `json:"appeared"`
+
+	// Disappeared is the collection of processes found only in the old
+	// collection.
+	Disappeared Processes `json:"disappeared"`
+
+	// Changed is the collection of processes found in both collections with
+	// one or more changed fields.
+	Changed []ProcessChange `json:"changed"`
+
+	// States is the number of processes in each process state found in
+	// either collection, sorted by state.
+	States []StateDelta `json:"states"`
+}
+
+// DiffProcesses generates a Diff of the specified old and new collections
+// of processes using the specified options.
+func DiffProcesses(oldProcs Processes, newProcs Processes, opts DiffOptions) Diff {
+	oldIndex := make(map[ProcessKey]Process, len(oldProcs))
+	for _, p := range oldProcs {
+		oldIndex[p.Key()] = p
+	}
+
+	var diff Diff
+	var common Processes
+
+	for _, p := range newProcs {
+		old, ok := oldIndex[p.Key()]
+		if !ok {
+			continue
+		}
+
+		common = append(common, p)
+
+		if fields := changedFields(old, p, opts); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, ProcessChange{
+				Old:    old,
+				New:    p,
+				Fields: fields,
+			})
+		}
+	}
+
+	diff.Appeared = newProcs.Exclude(common...)
+	diff.Disappeared = oldProcs.Exclude(common...)
+	diff.States = stateDeltas(oldProcs.Summary(SummaryOptions{}), newProcs.Summary(SummaryOptions{}))
+
+	return diff
+}
+
+// DiffSnapshots generates a Diff of the processes in the specified old and
+// new snapshots using the specified options.
+func DiffSnapshots(oldSnapshot Snapshot, newSnapshot Snapshot, opts DiffOptions) Diff {
+	return DiffProcesses(oldSnapshot.Processes, newSnapshot.Processes, opts)
+}
+
+// IsEmpty indicates whether no processes appeared, disappeared or changed.
+func (d Diff) IsEmpty() bool {
+	return len(d.Appeared) == 0 && len(d.Disappeared) == 0 && len(d.Changed) == 0
+}
+
+// ChangedStates returns the state deltas with a non-zero change in the
+// number of processes.
+func (d Diff) ChangedStates() []StateDelta {
+	deltas := make([]StateDelta, 0, len(d.States))
+	for _, delta := range d.States {
+		if delta.Delta() != 0 {
+			deltas = append(deltas, delta)
+		}
+	}
+
+	return deltas
+}
+
+// changedFields returns the list of fields which differ between the old and
+// new values of a process.
+func changedFields(old Process, p Process, opts DiffOptions) []string {
+	var fields []string
+
+	if !old.State.Equal(p.State) {
+		fields = append(fields, DiffFieldState)
+	}
+
+	if old.PPid != p.PPid {
+		fields = append(fields, DiffFieldPPid)
+	}
+
+	if old.Threads != p.Threads {
+		fields = append(fields, DiffFieldThreads)
+	}
+
+	memoryChanged := func(oldKB int, newKB int) bool {
+		delta := newKB - oldKB
+		if delta < 0 {
+			delta = -delta
+		}
+
+		return delta > 0 && delta >= opts.MemoryThresholdKB
+	}
+
+	if memoryChanged(old.VMRSSKB(), p.VMRSSKB()) {
+		fields = append(fields, DiffFieldVMRSS)
+	}
+
+	if memoryChanged(old.VMSwapKB(), p.VMSwapKB()) {
+		fields = append(fields, DiffFieldVMSwap)
+	}
+
+	return fields
+}
+
+// stateDeltas merges the state tallies of the specified old and new
+// summaries.
+func stateDeltas(oldSummary Summary, newSummary Summary) []StateDelta {
+	index := make(map[string]*StateDelta)
+
+	get := func(state string) *StateDelta {
+		delta, ok := index[state]
+		if !ok {
+			delta = &StateDelta{State: state}
+			index[state] = delta
+		}
+
+		return delta
+	}
+
+	for _, tally := range oldSummary.States {
+		get(tally.State).Old = tally.Count
+	}
+
+	for _, tally := range newSummary.States {
+		get(tally.State).New = tally.Count
+	}
+
+	deltas := make([]StateDelta, 0, len(index))
+	for _, delta := range index {
+		deltas = append(deltas, *delta)
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].State < deltas[j].State
+	})
+
+	return deltas
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// TestDiffProcesses asserts that processes are matched between collections
+// by process ID and start time and that changed fields are reported.
+func TestDiffProcesses(t *testing.T) {
+	t.Parallel()
+
+	base := Process{
+		Name:      "java",
+		Pid:       4321,
+		PPid:      1,
+		Threads:   87,
+		VMRSS:     "204800 kB",
+		VMSwap:    "10240 kB",
+		StartTime: 5000,
+		State:     KernelAnyProcessStateSleeping,
+	}
+
+	with := func(modify func(p *Process)) Process {
+		p := base
+		modify(&p)
+
+		return p
+	}
+
+	tests := map[string]struct {
+		oldProcs        Processes
+		newProcs        Processes
+		wantAppeared    []int
+		wantDisappeared []int
+		wantChanged     map[int][]string
+	}{
+		"unchanged": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{base},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+		},
+		"appeared and disappeared": {
+			oldProcs: Processes{
+				base,
+				{Name: "sshd", Pid: 900, PPid: 1, StartTime: 100, State: KernelAnyProcessStateSleeping},
+			},
+			newProcs: Processes{
+				base,
+				{Name: "cron", Pid: 950, PPid: 1, StartTime: 7000, State: KernelAnyProcessStateSleeping},
+			},
+			wantAppeared:    []int{950},
+			wantDisappeared: []int{900},
+		},
+		"pid reused with different start time": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.Name = "mysqld"; p.StartTime = 9000 })},
+			wantAppeared:    []int{4321},
+			wantDisappeared: []int{4321},
+		},
+		"state transition": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.State = KernelAnyProcessStateDiskSleep })},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+			wantChanged:     map[int][]string{4321: {DiffFieldState}},
+		},
+		"parent and threads changed": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.PPid = 2; p.Threads = 90 })},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+			wantChanged:     map[int][]string{4321: {DiffFieldPPid, DiffFieldThreads}},
+		},
+		"memory delta below threshold": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.VMRSS = "205823 kB"; p.VMSwap = "9217 kB" })},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+		},
+		"memory delta at threshold": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.VMRSS = "205824 kB" })},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+			wantChanged:     map[int][]string{4321: {DiffFieldVMRSS}},
+		},
+		"memory delta above threshold": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.VMRSS = "205825 kB"; p.VMSwap = "9215 kB" })},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+			wantChanged:     map[int][]string{4321: {DiffFieldVMRSS, DiffFieldVMSwap}},
+		},
+		"memory usage no longer listed": {
+			oldProcs:        Processes{base},
+			newProcs:        Processes{with(func(p *Process) { p.VMSwap = "" })},
+			wantAppeared:    []int{},
+			wantDisappeared: []int{},
+			wantChanged:     map[int][]string{4321: {DiffFieldVMSwap}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := DiffProcesses(tt.oldProcs, tt.newProcs, DiffOptions{MemoryThresholdKB: 1024})
+
+			if got := pids(diff.Appeared); !slices.Equal(got, tt.wantAppeared) {
+				t.Errorf("\nwant appeared %v\ngot appeared  %v", tt.wantAppeared, got)
+			}
+
+			if got := pids(diff.Disappeared); !slices.Equal(got, tt.wantDisappeared) {
+				t.Errorf("\nwant disappeared %v\ngot disappeared  %v", tt.wantDisappeared, got)
+			}
+
+			gotChanged := make(map[int][]string, len(diff.Changed))
+			for _, change := range diff.Changed {
+				gotChanged[change.New.Pid] = change.Fields
+			}
+
+			if len(tt.wantChanged) == 0 && len(gotChanged) == 0 {
+				return
+			}
+
+			if !reflect.DeepEqual(gotChanged, tt.wantChanged) {
+				t.Errorf("\nwant changed %v\ngot changed  %v", tt.wantChanged, gotChanged)
+			}
+		})
+	}
+}
+
+// TestDiffProcessesStates asserts that per-state process counts are compared
+// and that only states with a changed count are listed as changed.
+func TestDiffProcessesStates(t *testing.T) {
+	t.Parallel()
+
+	oldProcs := Processes{
+		{Name: "java", Pid: 4321, PPid: 1, StartTime: 5000, State: KernelAnyProcessStateSleeping},
+		{Name: "cron", Pid: 950, PPid: 1, StartTime: 700, State: KernelAnyProcessStateSleeping},
+	}
+
+	newProcs := Processes{
+		{Name: "java", Pid: 4321, PPid: 1, StartTime: 5000, State: KernelAnyProcessStateDiskSleep},
+		{Name: "cron", Pid: 950, PPid: 1, StartTime: 700, State: KernelAnyProcessStateSleeping},
+	}
+
+	diff := DiffProcesses(oldProcs, newProcs, DiffOptions{})
+
+	want := []StateDelta{
+		{State: KernelAnyProcessStateDiskSleep.String(), Old: 0, New: 1},
+		{State: KernelAnyProcessStateSleeping.String(), Old: 2, New: 1},
+	}
+
+	if got := diff.ChangedStates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, got)
+	}
+
+	if diff.IsEmpty() {
+		t.Errorf("want non-empty diff for state transition")
+	}
+
+	if !DiffProcesses(oldProcs, oldProcs, DiffOptions{}).IsEmpty() {
+		t.Errorf("want empty diff for identical collections")
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package procstate provides functionality used to evaluate processes for
+// problematic states.
+//
+// Process values are gathered from a proc filesystem using a Collector:
+//
+//	collector, err := procstate.NewCollector(
+//		procstate.WithProcRoot("/host/proc"),
+//		procstate.WithConcurrency(4),
+//		procstate.WithFilter(procstate.Not(procstate.IsKernelThread)),
+//	)
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	processes, err := collector.Collect()
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	summary := processes.Summary(procstate.SummaryOptions{})
+//	fmt.Println(summary.ServiceState().Label)
+//
+// The returned Processes collection may be filtered, grouped and sorted
+// using Predicate and CompareFunc values. Severity evaluation of process
+// states is driven by the table of known process states (see
+// SupportedProcessStates) and summarized by the Summary type. Report
+// builders for the evaluation results are provided by the reports
+// subpackage.
+//
+// # API stability
+//
+// The exported API of this package and its subpackages follows semantic
+// versioning. Exported identifiers will not be removed or changed in an
+// incompatible way without a major version change of this module. Output
+// formats of report builders are intended for human consumption and may
+// change between minor versions; use the Summary type for machine readable
+// results.
+package procstate
@@ -0,0 +1,150 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrProblemProcessesFound indicates that one or more "problematic"
+	// processes were found (e.g., uninterruptible disk sleep) which warrant
+	// surfacing the issue.
+	ErrProblemProcessesFound = errors.New("problematic processes found")
+
+	// ErrUnexpectedProcessStatesFound indicates that one or more processes
+	// were found in states not expected for the running kernel (e.g.,
+	// unrecognized or malformed states).
+	ErrUnexpectedProcessStatesFound = errors.New("processes in unexpected states found")
+
+	// ErrInvalidProcStatusLineFormat indicates that a status file within the
+	// /proc filesystem has an invalid key/value format.
+	ErrInvalidProcStatusLineFormat = errors.New("invalid format for proc status line")
+
+	// ErrInvalidProcStatusLineValue indicates that a status file within the
+	// /proc filesystem has an invalid value (wrong type) for a process
+	// property.
+	ErrInvalidProcStatusLineValue = errors.New("invalid value for proc status line")
+
+	// ErrInvalidProcStatLineFormat indicates that a stat file within the
+	// /proc filesystem has an invalid format.
+	ErrInvalidProcStatLineFormat = errors.New("invalid format for proc stat line")
+
+	// ErrInvalidProcStatLineValue indicates that a stat file within the
+	// /proc filesystem has an invalid value (wrong type) for a process
+	// property.
+	ErrInvalidProcStatLineValue = errors.New("invalid value for proc stat line")
+
+	// ErrMissingProcessPropertiesIndexEntry indicates that a requested
+	// process property entry is missing from the index.
+	ErrMissingProcessPropertiesIndexEntry = errors.New("entry missing from process properties index")
+
+	// ErrInvalidProcessPropertiesIndexCount indicates that a
+	// ProcessProperties index (map) is missing the minimum required entries
+	// used to populate specific fields of a Process.
+	ErrInvalidProcessPropertiesIndexCount = errors.New("minimum entries not present in process properties index")
+
+	// ErrParentProcessResolveFailure indicates that a failure occurred trying
+	// to determine the parent process
+	// ErrParentProcessResolveFailure = errors.New("unable to determine parent process")
+
+	// ErrMissingProcessEntry indicates that an expected process was not found
+	// in a given collection.
+	ErrMissingProcessEntry = errors.New("process missing from collection")
+
+	// ErrInvalidKernelRelease indicates that a kernel release value could
+	// not be parsed.
+	ErrInvalidKernelRelease = errors.New("invalid kernel release")
+
+	// ErrUnsupportedOS indicates that an attempt was made to use
+	// functionality not supported by the current operating system.
+	ErrUnsupportedOS = errors.New("unsupported operating system")
+
+	// ErrInvalidBootTime indicates that the system boot time could not be
+	// determined.
+	ErrInvalidBootTime = errors.New("invalid or missing boot time")
+
+	// ErrUnsupportedSnapshotFormat indicates that a snapshot uses a format
+	// version not supported by this package.
+	ErrUnsupportedSnapshotFormat = errors.New("unsupported snapshot format")
+
+	// ErrInvalidArchive indicates that a capture archive is malformed or
+	// contains unsupported or unsafe content.
+	ErrInvalidArchive = errors.New("invalid capture archive")
+
+	// ErrInvalidCollectorOption indicates that an invalid option value was
+	// specified when creating a Collector.
+	ErrInvalidCollectorOption = errors.New("invalid collector option")
+
+	// ErrInvalidProcLimitsFormat indicates that a limits file within the
+	// proc filesystem is not in the expected format.
+	ErrInvalidProcLimitsFormat = errors.New("invalid format for proc limits file")
+
+	// ErrInvalidSignalMask indicates that a signal mask value is not a
+	// valid hexadecimal bitmask.
+	ErrInvalidSignalMask = errors.New("invalid signal mask")
+)
+
+// ParseError records a failure to parse the content of a file within the
+// proc filesystem. The underlying error is one of the sentinel errors
+// provided by this package (e.g., ErrInvalidProcStatusLineValue) and may be
+// checked for using errors.Is.
+type ParseError struct {
+	// Path is the path to the file being parsed. This value is empty if the
+	// content was not read from a file.
+	Path string
+
+	// Line is the line number (starting at 1) of the offending content. This
+	// value is zero if the error does not apply to a specific line (e.g., a
+	// missing property).
+	Line int
+
+	// Key is the name of the property or field being parsed. This value may
+	// be empty.
+	Key string
+
+	// Value is the raw offending content.
+	Value string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error provides a human readable description of the parse error.
+func (e *ParseError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("parse error")
+
+	if e.Path != "" {
+		fmt.Fprintf(&b, " in file %s", e.Path)
+	}
+
+	if e.Line > 0 {
+		fmt.Fprintf(&b, " at line %d", e.Line)
+	}
+
+	if e.Key != "" {
+		fmt.Fprintf(&b, " for key %q", e.Key)
+	}
+
+	if e.Value != "" {
+		fmt.Fprintf(&b, " with value %q", e.Value)
+	}
+
+	fmt.Fprintf(&b, ": %v", e.Err)
+
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
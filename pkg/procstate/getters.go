@@ -0,0 +1,52 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+// SupportedProcessStates provides a list of the process states supported by
+// this package. Not all process states are supported by all kernel versions.
+func SupportedProcessStates() []ProcessState {
+	states := make([]ProcessState, len(knownProcessStates))
+	copy(states, knownProcessStates)
+
+	return states
+}
+
+// KnownProblemProcessStates provides a list of the process states known to be
+// problematic.
+func KnownProblemProcessStates() []ProcessState {
+	return append(
+		KnownCriticalProcessStates(),
+		KnownWarningProcessStates()...,
+	)
+}
+
+// KnownWarningProcessStates provides a list of the process states known to be
+// problematic and warrant a WARNING, but not so severe to be considered
+// CRITICAL.
+func KnownWarningProcessStates() []ProcessState {
+	return processStatesBySeverity(SeverityWarning)
+}
+
+// KnownCriticalProcessStates returns a list of the process states known to be
+// problematic. These states are considered CRITICAL.
+func KnownCriticalProcessStates() []ProcessState {
+	return processStatesBySeverity(SeverityCritical)
+}
+
+// processStatesBySeverity is a helper function used to filter the table of
+// known process states by the given severity.
+func processStatesBySeverity(severity Severity) []ProcessState {
+	states := make([]ProcessState, 0)
+	for _, state := range knownProcessStates {
+		if state.Severity == severity {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hungTaskRegex matches the kernel hung task detector message emitted for a
+// blocked task. The task name is the comm value of the task (at most 15
+// characters) and may contain colons (e.g., 'kworker/0:1'); backtracking
+// ensures that the last colon followed by the PID and the remainder of the
+// message is used as the name/PID separator. Any prefix (e.g., a dmesg
+// timestamp or kmsg record header) is ignored.
+//
+// Example messages:
+//
+// INFO: task jbd2/dm-0-8:583 blocked for more than 120 seconds.
+// [ 1234.567890] INFO: task kworker/u8:2:1234 blocked for more than 122 seconds.
+// 3,1234,5678901,-;INFO: task java:4321 blocked for more than 245 seconds.
+//
+// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/kernel/hung_task.c
+var hungTaskRegex = regexp.MustCompile(`INFO: task (.{1,15}):(\d+) blocked for more than (\d+) seconds`)
+
+// HungTask represents a kernel hung task detector report for a specific
+// process.
+type HungTask struct {
+	// Name is the task name as reported by the kernel.
+	Name string
+
+	// Pid is the process ID reported by the kernel.
+	Pid int
+
+	// BlockedSeconds is the number of seconds the kernel reported the task
+	// as blocked. If multiple reports are found for the same process the
+	// largest value is retained.
+	BlockedSeconds int
+}
+
+// HungTasks is a collection of HungTask values.
+type HungTasks []HungTask
+
+// HungTaskSettings represents the kernel hung task detector settings exposed
+// via the proc filesystem. These settings are provided as context for hung
+// task reports.
+type HungTaskSettings struct {
+	// TimeoutSecs is the number of seconds a task must be blocked before the
+	// kernel reports it. A value of 0 indicates that the detector is
+	// disabled.
+	TimeoutSecs int
+
+	// Warnings is the remaining number of hung task warnings the kernel will
+	// emit. A value of -1 indicates an unlimited number of warnings.
+	Warnings int
+}
+
+// ParseHungTasks parses kernel log content (exposed as an io.Reader) and
+// returns a collection of hung task reports. The content may be sourced from
+// /dev/kmsg, dmesg output or a journal export; lines not matching the hung
+// task message format are ignored.
+func ParseHungTasks(r io.Reader) (HungTasks, error) {
+	index := make(map[int]int)
+	tasks := make(HungTasks, 0)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		matches := hungTaskRegex.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+
+		pid, err := strconv.Atoi(matches[2])
+		if err != nil {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(matches[3])
+		if err != nil {
+			continue
+		}
+
+		if i, ok := index[pid]; ok {
+			if seconds > tasks[i].BlockedSeconds {
+				tasks[i].BlockedSeconds = seconds
+			}
+
+			continue
+		}
+
+		index[pid] = len(tasks)
+		tasks = append(tasks, HungTask{
+			Name:           matches[1],
+			Pid:            pid,
+			BlockedSeconds: seconds,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse kernel log content: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// HungTasksFromFile parses the specified dmesg or journal export file and
+// returns a collection of hung task reports.
+func HungTasksFromFile(filename string) (HungTasks, error) {
+	data, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	tasks, err := ParseHungTasks(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to obtain hung tasks from file %s: %w",
+			filename,
+			err,
+		)
+	}
+
+	return tasks, nil
+}
+
+// HungTasksFromKmsg reads all records currently available from the kernel
+// log buffer (/dev/kmsg) without blocking and returns a collection of hung
+// task reports.
+func HungTasksFromKmsg() (HungTasks, error) {
+	data, err := readKmsg(KmsgDevice)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseHungTasks(bytes.NewReader(data))
+}
+
+// GetHungTaskSettings retrieves the kernel hung task detector settings from
+// the given base path (usually "/proc").
+func GetHungTaskSettings(path string) (HungTaskSettings, error) {
+	readInt := func(filename string) (int, error) {
+		qualifiedPath := filepath.Join(path, filename)
+		data, err := os.ReadFile(filepath.Clean(qualifiedPath))
+		if err != nil {
+			return 0, fmt.Errorf("failed to read file %s: %w", qualifiedPath, err)
+		}
+
+		value := strings.TrimSpace(string(data))
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed to convert value %s from file %s as number: %w",
+				value,
+				qualifiedPath,
+				err,
+			)
+		}
+
+		return n, nil
+	}
+
+	var settings HungTaskSettings
+	var err error
+
+	if settings.TimeoutSecs, err = readInt(ProcHungTaskTimeoutFilename); err != nil {
+		return HungTaskSettings{}, err
+	}
+
+	if settings.Warnings, err = readInt(ProcHungTaskWarningsFilename); err != nil {
+		return HungTaskSettings{}, err
+	}
+
+	return settings, nil
+}
+
+// Pid returns the HungTask from the collection matching the specified
+// process ID value and a boolean indicating whether a match was found.
+func (hts HungTasks) Pid(pid int) (HungTask, bool) {
+	for _, ht := range hts {
+		if ht.Pid == pid {
+			return ht, true
+		}
+	}
+
+	return HungTask{}, false
+}
+
+// AnnotateHungTasks returns a copy of the collection with the
+// KernelBlockedSeconds field set for each Process matching a given hung task
+// report. Matching is performed using the process ID and name values.
+func (ps Processes) AnnotateHungTasks(tasks HungTasks) Processes {
+	annotated := make(Processes, 0, len(ps))
+	for _, p := range ps {
+		if ht, ok := tasks.Pid(p.Pid); ok && ht.Name == p.Name {
+			p.KernelBlockedSeconds = ht.BlockedSeconds
+		}
+		annotated = append(annotated, p)
+	}
+
+	return annotated
+}
+
+// KernelBlocked returns each Process from the collection which the kernel has
+// reported as blocked. The returned collection may be empty if no processes
+// have been reported as blocked.
+func (ps Processes) KernelBlocked() Processes {
+	return ps.Filter(func(p Process) bool {
+		return p.KernelBlockedSeconds > 0
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseHungTasks asserts that hung task reports are parsed from kernel
+// log content in the formats emitted by dmesg, /dev/kmsg and journalctl.
+func TestParseHungTasks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input string
+		want  HungTasks
+	}{
+		"dmesg without timestamp": {
+			input: "INFO: task jbd2/dm-0-8:583 blocked for more than 120 seconds.",
+			want:  HungTasks{{Name: "jbd2/dm-0-8", Pid: 583, BlockedSeconds: 120}},
+		},
+		"dmesg with timestamp": {
+			input: "[ 1234.567890] INFO: task java:4321 blocked for more than 245 seconds.",
+			want:  HungTasks{{Name: "java", Pid: 4321, BlockedSeconds: 245}},
+		},
+		"dmesg with timestamp and name containing colon": {
+			input: "[1987654.123456] INFO: task kworker/u8:2:1234 blocked for more than 122 seconds.",
+			want:  HungTasks{{Name: "kworker/u8:2", Pid: 1234, BlockedSeconds: 122}},
+		},
+		"name containing multiple colons": {
+			input: "[  605.021324] INFO: task flush-253:0:2871 blocked for more than 120 seconds.",
+			want:  HungTasks{{Name: "flush-253:0", Pid: 2871, BlockedSeconds: 120}},
+		},
+		"name containing space": {
+			input: "[  721.093211] INFO: task Web Content:9911 blocked for more than 480 seconds.",
+			want:  HungTasks{{Name: "Web Content", Pid: 9911, BlockedSeconds: 480}},
+		},
+		"kmsg record": {
+			input: "3,1234,5678901,-;INFO: task java:4321 blocked for more than 245 seconds.",
+			want:  HungTasks{{Name: "java", Pid: 4321, BlockedSeconds: 245}},
+		},
+		"journal export": {
+			input: "Oct 19 07:07:01 host1 kernel: INFO: task mysqld:2210 blocked for more than 122 seconds.",
+			want:  HungTasks{{Name: "mysqld", Pid: 2210, BlockedSeconds: 122}},
+		},
+		"related and unrelated lines ignored": {
+			input: strings.Join([]string{
+				"[  605.021324] INFO: task xfsaild/dm-2:612 blocked for more than 120 seconds.",
+				"[  605.021330]       Not tainted 3.10.0-1160.el7.x86_64 #1",
+				`[  605.021331] "echo 0 > /proc/sys/kernel/hung_task_timeout_secs" disables this message.`,
+				"[  605.021333] xfsaild/dm-2    D ffff9b4a7fc1acc0     0   612      2 0x00000000",
+				"[  605.021340] Call Trace:",
+				"[  700.000001] task blocked:1 on an unrelated message",
+			}, "\n"),
+			want: HungTasks{{Name: "xfsaild/dm-2", Pid: 612, BlockedSeconds: 120}},
+		},
+		"largest duration retained for repeated reports": {
+			input: strings.Join([]string{
+				"[  245.000000] INFO: task java:4321 blocked for more than 120 seconds.",
+				"[  365.000000] INFO: task java:4321 blocked for more than 240 seconds.",
+				"[  485.000000] INFO: task java:4321 blocked for more than 120 seconds.",
+			}, "\n"),
+			want: HungTasks{{Name: "java", Pid: 4321, BlockedSeconds: 240}},
+		},
+		"no reports": {
+			input: "[    0.000000] Linux version 5.14.0-362.8.1.el9_3.x86_64",
+			want:  HungTasks{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseHungTasks(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("failed to parse hung tasks: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\nwant %+v\ngot  %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAnnotateHungTasks asserts that the blocked duration of a hung task
+// report is only recorded for a process matching both the process ID and
+// name of the report (i.e., not for a process which reuses the process ID of
+// a reported task) and that the original collection is left unmodified.
+func TestAnnotateHungTasks(t *testing.T) {
+	t.Parallel()
+
+	java := Process{Name: "java", Pid: 4321, PPid: 1, State: KernelAnyProcessStateDiskSleep}
+	jbd2 := Process{Name: "jbd2/dm-0-8", Pid: 583, PPid: KThreaddPID, State: KernelAnyProcessStateDiskSleep}
+	bash := Process{Name: "bash", Pid: 100, PPid: 1, State: KernelAnyProcessStateSleeping}
+
+	tests := map[string]struct {
+		tasks       HungTasks
+		wantBlocked map[int]int
+	}{
+		"pid and name match": {
+			tasks: HungTasks{
+				{Name: "java", Pid: 4321, BlockedSeconds: 245},
+				{Name: "jbd2/dm-0-8", Pid: 583, BlockedSeconds: 120},
+			},
+			wantBlocked: map[int]int{4321: 245, 583: 120},
+		},
+		"pid reused by another process": {
+			tasks: HungTasks{
+				{Name: "rsync", Pid: 4321, BlockedSeconds: 245},
+				{Name: "jbd2/dm-0-8", Pid: 583, BlockedSeconds: 120},
+			},
+			wantBlocked: map[int]int{583: 120},
+		},
+		"name matches another pid": {
+			tasks: HungTasks{
+				{Name: "java", Pid: 9911, BlockedSeconds: 245},
+			},
+			wantBlocked: map[int]int{},
+		},
+		"no reports": {
+			wantBlocked: map[int]int{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			processes := Processes{bash, java, jbd2}
+
+			annotated := processes.AnnotateHungTasks(tt.tasks)
+
+			if !reflect.DeepEqual(pids(annotated), pids(processes)) {
+				t.Fatalf("\nwant pids %v\ngot pids  %v", pids(processes), pids(annotated))
+			}
+
+			got := make(map[int]int)
+			for _, p := range annotated.KernelBlocked() {
+				got[p.Pid] = p.KernelBlockedSeconds
+			}
+
+			if !reflect.DeepEqual(got, tt.wantBlocked) {
+				t.Errorf("\nwant %v\ngot  %v", tt.wantBlocked, got)
+			}
+
+			if blocked := processes.KernelBlocked(); len(blocked) != 0 {
+				t.Errorf("want original collection unmodified, got blocked processes %+v", blocked)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"regexp"
+)
+
+// IgnoreRules is a collection of rules used to identify known-benign
+// problem processes which should not be considered when evaluating the
+// severity of a collection of processes. A problem process or a process in
+// a state not known to this package matching any one rule is ignored;
+// processes in known OK states are always evaluated.
+type IgnoreRules struct {
+	// Names is a list of process names to ignore. Matches are
+	// case-sensitive.
+	Names []string
+
+	// NameRegexes is a list of regular expressions matched against process
+	// names.
+	NameRegexes []*regexp.Regexp
+
+	// CmdlineRegexes is a list of regular expressions matched against the
+	// full command line of a process.
+	CmdlineRegexes []*regexp.Regexp
+
+	// Users is a list of usernames or numeric user ID values. Matching is
+	// performed against the real user ID of a process.
+	Users []string
+
+	// ParentNames is a list of parent process names. A process is ignored if
+	// its parent process has a matching name.
+	ParentNames []string
+
+	// Cgroups is a list of cgroup path substrings. A process is ignored if
+	// any of its cgroup paths contains a listed value.
+	Cgroups []string
+}
+
+// IsEmpty indicates whether no rules have been specified.
+func (r IgnoreRules) IsEmpty() bool {
+	return len(r.Names) == 0 &&
+		len(r.NameRegexes) == 0 &&
+		len(r.CmdlineRegexes) == 0 &&
+		len(r.Users) == 0 &&
+		len(r.ParentNames) == 0 &&
+		len(r.Cgroups) == 0
+}
+
+// Matches indicates whether the given Process matches any of the rules. The
+// collection of gathered Process values is provided in order to resolve
+// dependencies between processes (e.g., ancestry).
+//
+// Properties which require reading additional files from the proc
+// filesystem (e.g., cmdline, cgroup) are only retrieved if applicable rules
+// are specified. If those properties cannot be retrieved (e.g., the process
+// has exited) the associated rules are not considered a match.
+func (r IgnoreRules) Matches(p Process, ps Processes) bool {
+	return r.predicate(ps)(p)
+}
+
+// predicate returns a Predicate which matches a Process if any of the rules
+// match. The collection of gathered Process values is used to resolve
+// dependencies between processes (e.g., ancestry).
+func (r IgnoreRules) predicate(ps Processes) Predicate {
+	preds := make([]Predicate, 0, 6)
+
+	if len(r.Names) > 0 {
+		preds = append(preds, ByName(r.Names...))
+	}
+
+	if len(r.NameRegexes) > 0 {
+		preds = append(preds, ByNameRegex(r.NameRegexes...))
+	}
+
+	if len(r.Users) > 0 {
+		preds = append(preds, ByUser(r.Users...))
+	}
+
+	if len(r.ParentNames) > 0 {
+		preds = append(preds, ByParentName(ps, r.ParentNames...))
+	}
+
+	if len(r.CmdlineRegexes) > 0 {
+		preds = append(preds, ByCmdlineRegex(r.CmdlineRegexes...))
+	}
+
+	if len(r.Cgroups) > 0 {
+		preds = append(preds, ByCgroup(r.Cgroups...))
+	}
+
+	return Or(preds...)
+}
+
+// Ignore evaluates the collection against the specified rules and returns
+// the Process values which remain to be evaluated along with the problem
+// Process values which matched one or more rules. Rules only exempt problem
+// processes and processes in unknown states (which are reported as
+// unexpected states regardless of the kernel release) from severity
+// evaluation; processes in known OK states are always returned for
+// evaluation so that they remain counted by state. If no rules are
+// specified all Process values in the collection are returned for
+// evaluation.
+func (ps Processes) Ignore(rules IgnoreRules) (Processes, Processes) {
+	if rules.IsEmpty() {
+		return ps, Processes{}
+	}
+
+	// Evaluate each problem or unknown state process once; some rules require reading
+	// additional files from the proc filesystem.
+	matches := rules.predicate(ps)
+	evaluated := make(Processes, 0, len(ps))
+	ignored := make(Processes, 0)
+	for _, p := range ps {
+		switch {
+		case (!p.IsOKState() || !p.State.IsKnown()) && matches(p):
+			ignored = append(ignored, p)
+		default:
+			evaluated = append(evaluated, p)
+		}
+	}
+
+	return evaluated, ignored
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// newTestProcDir creates a /proc/[pid] style directory containing the given
+// files (name to content) and returns the path to the directory.
+func newTestProcDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create file %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+// pids returns the process ID values of the given processes in collection
+// order.
+func pids(ps Processes) []int {
+	values := make([]int, 0, len(ps))
+	for _, p := range ps {
+		values = append(values, p.Pid)
+	}
+
+	return values
+}
+
+// TestIgnore asserts that each rule type exempts matching problem processes
+// and processes in unknown states from evaluation while matching processes
+// in known OK states remain evaluated.
+func TestIgnore(t *testing.T) {
+	t.Parallel()
+
+	backupDir := newTestProcDir(t, map[string]string{
+		ProcCmdlineFilename: "/opt/vendor/bin/backup-agent\x00--daemon\x00",
+		ProcCgroupFilename:  "0::/system.slice/backup-agent.service\n",
+	})
+
+	okBackupDir := newTestProcDir(t, map[string]string{
+		ProcCmdlineFilename: "/opt/vendor/bin/backup-agent\x00--scheduler\x00",
+		ProcCgroupFilename:  "0::/system.slice/backup-agent.service\n",
+	})
+
+	otherDir := newTestProcDir(t, map[string]string{
+		ProcCmdlineFilename: "/usr/sbin/mysqld\x00",
+		ProcCgroupFilename:  "0::/system.slice/mysqld.service\n",
+	})
+
+	// The proc files of zombie processes are not readable.
+	zombieDir := newTestProcDir(t, nil)
+
+	// Processes are not in problem states unless noted otherwise. The
+	// backup agent and its worker share a parent (the vendor supervisor).
+	processes := Processes{
+		{Name: "systemd", Pid: 1, PPid: 0, State: KernelAnyProcessStateSleeping,
+			AllProperties: Properties{ProcessUIDField: "0\t0\t0\t0"}},
+		{Name: "supervisor", Pid: 100, PPid: 1, State: KernelAnyProcessStateSleeping,
+			AllProperties: Properties{ProcessUIDField: "0\t0\t0\t0"}},
+		{Name: "backup-agent", Pid: 101, PPid: 100, State: KernelAnyProcessStateDiskSleep,
+			AllProperties: Properties{ProcessUIDField: "0\t0\t0\t0"}, ProcDir: backupDir},
+		{Name: "backup-agent", Pid: 102, PPid: 100, State: KernelAnyProcessStateSleeping,
+			AllProperties: Properties{ProcessUIDField: "0\t0\t0\t0"}, ProcDir: okBackupDir},
+		{Name: "mysqld", Pid: 200, PPid: 1, State: KernelAnyProcessStateDiskSleep,
+			AllProperties: Properties{ProcessUIDField: "27\t27\t27\t27"}, ProcDir: otherDir},
+		{Name: "defunct", Pid: 300, PPid: 1, State: KernelAnyProcessStateZombie,
+			AllProperties: Properties{ProcessUIDField: "1000\t1000\t1000\t1000"}, ProcDir: zombieDir},
+		{Name: "odd", Pid: 400, PPid: 1, State: ProcessState{Code: 'Q', Description: "queued", Category: StateCategoryUnknown},
+			AllProperties: Properties{ProcessUIDField: "1000\t1000\t1000\t1000"}, ProcDir: zombieDir},
+	}
+
+	tests := map[string]struct {
+		rules       IgnoreRules
+		wantIgnored []int
+	}{
+		"no rules": {
+			rules:       IgnoreRules{},
+			wantIgnored: []int{},
+		},
+		"name": {
+			rules:       IgnoreRules{Names: []string{"backup-agent"}},
+			wantIgnored: []int{101},
+		},
+		"name is case-sensitive": {
+			rules:       IgnoreRules{Names: []string{"Backup-Agent"}},
+			wantIgnored: []int{},
+		},
+		"name regex": {
+			rules:       IgnoreRules{NameRegexes: []*regexp.Regexp{regexp.MustCompile(`^backup-`)}},
+			wantIgnored: []int{101},
+		},
+		"cmdline regex": {
+			rules:       IgnoreRules{CmdlineRegexes: []*regexp.Regexp{regexp.MustCompile(`^/opt/vendor/.* --daemon$`)}},
+			wantIgnored: []int{101},
+		},
+		"cmdline regex without readable cmdline": {
+			rules:       IgnoreRules{CmdlineRegexes: []*regexp.Regexp{regexp.MustCompile(`.*`)}},
+			wantIgnored: []int{101, 200},
+		},
+		"user by numeric ID": {
+			rules:       IgnoreRules{Users: []string{"27"}},
+			wantIgnored: []int{200},
+		},
+		"user by username": {
+			rules:       IgnoreRules{Users: []string{"root"}},
+			wantIgnored: []int{101},
+		},
+		"user matching OK processes": {
+			rules:       IgnoreRules{Users: []string{"0"}},
+			wantIgnored: []int{101},
+		},
+		"parent name": {
+			rules:       IgnoreRules{ParentNames: []string{"supervisor"}},
+			wantIgnored: []int{101},
+		},
+		"parent name of OK process": {
+			rules:       IgnoreRules{ParentNames: []string{"systemd"}},
+			wantIgnored: []int{200, 300, 400},
+		},
+		"cgroup": {
+			rules:       IgnoreRules{Cgroups: []string{"backup-agent.service"}},
+			wantIgnored: []int{101},
+		},
+		"unknown state": {
+			rules:       IgnoreRules{Names: []string{"odd"}},
+			wantIgnored: []int{400},
+		},
+		"any rule matches": {
+			rules: IgnoreRules{
+				Names:   []string{"defunct"},
+				Cgroups: []string{"mysqld.service"},
+			},
+			wantIgnored: []int{200, 300},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			evaluated, ignored := processes.Ignore(tt.rules)
+
+			if got := pids(ignored); !slices.Equal(got, tt.wantIgnored) {
+				t.Errorf("\nwant ignored %v\ngot ignored  %v", tt.wantIgnored, got)
+			}
+
+			if len(evaluated)+len(ignored) != len(processes) {
+				t.Errorf(
+					"want %d processes in total, got %d evaluated and %d ignored",
+					len(processes),
+					len(evaluated),
+					len(ignored),
+				)
+			}
+
+			for _, p := range ignored {
+				if p.IsOKState() && p.State.IsKnown() {
+					t.Errorf("process %d in known OK state %s was ignored", p.Pid, p.State)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// KernelRelease represents the release of the running kernel.
+type KernelRelease struct {
+	// Release is the full kernel release value (e.g.,
+	// 5.14.0-362.8.1.el9_3.x86_64).
+	Release string
+
+	// Major is the major version number of the kernel release.
+	Major int
+
+	// Minor is the minor version number of the kernel release.
+	Minor int
+
+	// Patch is the patch level of the kernel release. This value is zero
+	// if the release does not include a patch level (e.g., 3.0).
+	Patch int
+}
+
+// ParseKernelRelease parses the given kernel release value and returns a
+// KernelRelease value or an error if one occurs.
+func ParseKernelRelease(release string) (KernelRelease, error) {
+	release = strings.TrimSpace(release)
+
+	// Version numbers are separated from the remainder of the release value
+	// by either a dash (e.g., 3.10.0-1160.el7.x86_64) or a plus sign (e.g.,
+	// 6.1.0+).
+	version, _, _ := strings.Cut(release, "-")
+	version, _, _ = strings.Cut(version, "+")
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return KernelRelease{}, fmt.Errorf(
+			"failed to parse kernel release %q: %w",
+			release,
+			ErrInvalidKernelRelease,
+		)
+	}
+
+	// Signs are not valid in version numbers.
+	major, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return KernelRelease{}, fmt.Errorf(
+			"failed to convert major version %s of kernel release %q as number: %w",
+			parts[0],
+			release,
+			ErrInvalidKernelRelease,
+		)
+	}
+
+	minor, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return KernelRelease{}, fmt.Errorf(
+			"failed to convert minor version %s of kernel release %q as number: %w",
+			parts[1],
+			release,
+			ErrInvalidKernelRelease,
+		)
+	}
+
+	var patch uint64
+	if len(parts) > 2 {
+		patch, err = strconv.ParseUint(parts[2], 10, 16)
+		if err != nil {
+			return KernelRelease{}, fmt.Errorf(
+				"failed to convert patch level %s of kernel release %q as number: %w",
+				parts[2],
+				release,
+				ErrInvalidKernelRelease,
+			)
+		}
+	}
+
+	return KernelRelease{
+		Release: release,
+		Major:   int(major),
+		Minor:   int(minor),
+		Patch:   int(patch),
+	}, nil
+}
+
+// GetKernelRelease retrieves the release of the running kernel from the
+// given base path (usually "/proc").
+func GetKernelRelease(path string) (KernelRelease, error) {
+	qualifiedPath := filepath.Join(path, ProcKernelReleaseFilename)
+	data, err := os.ReadFile(filepath.Clean(qualifiedPath))
+	if err != nil {
+		return KernelRelease{},
+			fmt.Errorf("failed to read file %s: %w", qualifiedPath, err)
+	}
+
+	return ParseKernelRelease(string(data))
+}
+
+// String provides the full kernel release value.
+func (kr KernelRelease) String() string {
+	return kr.Release
+}
+
+// AtLeast indicates whether the kernel release is the same or newer than the
+// specified major and minor version.
+func (kr KernelRelease) AtLeast(major int, minor int) bool {
+	return kr.Major > major || (kr.Major == major && kr.Minor >= minor)
+}
+
+// KernelGeneration returns the baseline kernel version representing the
+// family of kernel releases which emit the same set of process states.
+//
+// Generations are split at the releases where the wakekill, waking and
+// lowercase dead and tracing stop states were introduced (2.6.33) and where
+// the idle state was introduced and the wakekill, waking and lowercase dead
+// states were dropped (4.14). The parked state was introduced by the 3.9
+// kernel; as the parked state is not a problem state, it is deliberately
+// expected for the 2.6.33 through 3.8 releases which never emit it instead
+// of introducing another generation.
+func (kr KernelRelease) KernelGeneration() string {
+	switch {
+	case kr.AtLeast(4, 14):
+		return KernelGeneration418
+
+	case kr.AtLeast(3, 0), kr.Major == 2 && kr.Minor == 6 && kr.Patch >= 33:
+		return KernelGeneration310
+
+	default:
+		return KernelGeneration2632
+	}
+}
+
+// ExpectedProcessStates returns the list of process states expected to be
+// emitted by the kernel release.
+func (kr KernelRelease) ExpectedProcessStates() []ProcessState {
+	generation := kr.KernelGeneration()
+
+	states := make([]ProcessState, 0, len(knownProcessStates))
+	for _, state := range knownProcessStates {
+		if state.EmittedBy(generation) {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
+
+// IsExpectedState indicates whether the process state is in the specified
+// list of expected process states. Unlike other comparisons, the state code
+// and description must match exactly; a legacy state emitted by a newer
+// kernel is not expected.
+func (p Process) IsExpectedState(expected []ProcessState) bool {
+	for _, state := range expected {
+		if p.State.String() == state.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnexpectedStates returns each Process from the collection that is not in
+// one of the specified expected states. This includes process states not
+// known to this package, malformed state values and states not emitted by
+// the running kernel. The returned collection may be empty if all processes
+// are in expected states.
+func (ps Processes) UnexpectedStates(expected []ProcessState) Processes {
+	return ps.Filter(func(p Process) bool {
+		return !p.IsExpectedState(expected)
+	})
+}
+
+// ServiceStateWithUnexpected returns the appropriate Service Check Status
+// label and exit code for the collection's evaluation results taking into
+// account processes in states not in the specified list of expected states.
+// If any such processes are found the more severe of the specified severity
+// and the result of evaluating known process states is returned.
+func (ps Processes) ServiceStateWithUnexpected(expected []ProcessState, severity nagios.ServiceState) nagios.ServiceState {
+	state := ps.ServiceState()
+
+	if len(ps.UnexpectedStates(expected)) == 0 {
+		return state
+	}
+
+	return WorstServiceState(state, severity)
+}
+
+// WorstServiceState returns the most severe of the specified service states.
+// Service states are ranked from least to most severe as OK, WARNING,
+// UNKNOWN and CRITICAL.
+func WorstServiceState(states ...nagios.ServiceState) nagios.ServiceState {
+	rank := func(state nagios.ServiceState) int {
+		switch state.ExitCode {
+		case nagios.StateCRITICALExitCode:
+			return 3
+		case nagios.StateUNKNOWNExitCode:
+			return 2
+		case nagios.StateWARNINGExitCode:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	worst := nagios.ServiceState{
+		Label:    nagios.StateOKLabel,
+		ExitCode: nagios.StateOKExitCode,
+	}
+	for _, state := range states {
+		if rank(state) > rank(worst) {
+			worst = state
+		}
+	}
+
+	return worst
+}
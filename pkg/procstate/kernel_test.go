@@ -0,0 +1,242 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// TestParseKernelRelease asserts that version numbers are parsed from real
+// and distro-specific kernel release values and that malformed values are
+// rejected.
+func TestParseKernelRelease(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		release   string
+		wantMajor int
+		wantMinor int
+		wantPatch int
+		wantErr   bool
+	}{
+		"RHEL 6":                   {release: "2.6.32-754.el6.x86_64", wantMajor: 2, wantMinor: 6, wantPatch: 32},
+		"RHEL 7":                   {release: "3.10.0-1160.el7.x86_64", wantMajor: 3, wantMinor: 10},
+		"RHEL 8":                   {release: "4.18.0-513.5.1.el8_9.x86_64", wantMajor: 4, wantMinor: 18},
+		"RHEL 9":                   {release: "5.14.0-362.8.1.el9_3.x86_64", wantMajor: 5, wantMinor: 14},
+		"RHEL 9 realtime":          {release: "5.14.0-362.8.1.el9_3.x86_64+rt", wantMajor: 5, wantMinor: 14},
+		"trailing newline":         {release: "5.14.0-362.8.1.el9_3.x86_64\n", wantMajor: 5, wantMinor: 14},
+		"Ubuntu":                   {release: "5.15.0-91-generic", wantMajor: 5, wantMinor: 15},
+		"Debian":                   {release: "6.1.0-18-amd64", wantMajor: 6, wantMinor: 1},
+		"Oracle UEK":               {release: "5.15.0-200.131.27.el8uek.x86_64", wantMajor: 5, wantMinor: 15},
+		"SUSE":                     {release: "5.14.21-150500.55.39-default", wantMajor: 5, wantMinor: 14, wantPatch: 21},
+		"Arch":                     {release: "6.7.4-arch1-1", wantMajor: 6, wantMinor: 7, wantPatch: 4},
+		"WSL":                      {release: "5.15.146.1-microsoft-standard-WSL2", wantMajor: 5, wantMinor: 15, wantPatch: 146},
+		"release candidate":        {release: "6.8.0-rc3", wantMajor: 6, wantMinor: 8},
+		"plus suffix":              {release: "6.1.0+", wantMajor: 6, wantMinor: 1},
+		"major and minor only":     {release: "3.0", wantMajor: 3, wantMinor: 0},
+		"2.6 patch level":          {release: "2.6.33.20", wantMajor: 2, wantMinor: 6, wantPatch: 33},
+		"empty":                    {release: "", wantErr: true},
+		"whitespace":               {release: " \n", wantErr: true},
+		"major only":               {release: "5-generic", wantErr: true},
+		"non-numeric":              {release: "linux-generic", wantErr: true},
+		"non-numeric minor":        {release: "5.x.0-1", wantErr: true},
+		"leading separator":        {release: "-5.14.0", wantErr: true},
+		"negative minor":           {release: "5.-14.0", wantErr: true},
+		"signed major":             {release: "+5.14.0", wantErr: true},
+		"minor with suffix":        {release: "4.18rc1", wantErr: true},
+		"patch with suffix":        {release: "2.6.33rc1", wantErr: true},
+		"version number too large": {release: "99999999999.1", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kr, err := ParseKernelRelease(tt.release)
+			switch {
+			case tt.wantErr && err == nil:
+				t.Fatalf("want error for %q, got %+v", tt.release, kr)
+			case tt.wantErr && !errors.Is(err, ErrInvalidKernelRelease):
+				t.Fatalf("want error wrapping %v, got %v", ErrInvalidKernelRelease, err)
+			case tt.wantErr:
+				return
+			case err != nil:
+				t.Fatalf("failed to parse %q: %v", tt.release, err)
+			}
+
+			if kr.Major != tt.wantMajor || kr.Minor != tt.wantMinor || kr.Patch != tt.wantPatch {
+				t.Errorf(
+					"want version %d.%d.%d, got %d.%d.%d",
+					tt.wantMajor,
+					tt.wantMinor,
+					tt.wantPatch,
+					kr.Major,
+					kr.Minor,
+					kr.Patch,
+				)
+			}
+		})
+	}
+}
+
+// TestExpectedProcessStates asserts that the kernel generation and expected
+// process states are selected correctly for each kernel release, including
+// the boundaries where the set of emitted states changed. The parked state
+// is expected for releases before 3.9 which never emit it.
+func TestExpectedProcessStates(t *testing.T) {
+	t.Parallel()
+
+	legacy2632 := []string{
+		"R (running)", "S (sleeping)", "D (disk sleep)", "T (stopped)",
+		"Z (zombie)", "X (dead)", "T (tracing stop)",
+	}
+
+	legacy310 := []string{
+		"R (running)", "S (sleeping)", "D (disk sleep)", "T (stopped)",
+		"Z (zombie)", "X (dead)", "x (dead)", "K (wakekill)", "W (waking)",
+		"t (tracing stop)", "P (parked)",
+	}
+
+	current := []string{
+		"R (running)", "S (sleeping)", "D (disk sleep)", "T (stopped)",
+		"Z (zombie)", "X (dead)", "t (tracing stop)", "I (idle)",
+		"P (parked)",
+	}
+
+	tests := map[string]struct {
+		release        string
+		wantGeneration string
+		wantStates     []string
+	}{
+		"RHEL 6":            {release: "2.6.32-754.el6.x86_64", wantGeneration: KernelGeneration2632, wantStates: legacy2632},
+		"before 2.6.33":     {release: "2.6.32", wantGeneration: KernelGeneration2632, wantStates: legacy2632},
+		"2.6.33 boundary":   {release: "2.6.33", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"last 2.6 release":  {release: "2.6.39.4", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"3.0 release":       {release: "3.0.0", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"before parked":     {release: "3.8.13", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"parked introduced": {release: "3.9.0", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"RHEL 7":            {release: "3.10.0-1160.el7.x86_64", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"before 4.14":       {release: "4.13.16", wantGeneration: KernelGeneration310, wantStates: legacy310},
+		"4.14 boundary":     {release: "4.14.0", wantGeneration: KernelGeneration418, wantStates: current},
+		"RHEL 8":            {release: "4.18.0-513.5.1.el8_9.x86_64", wantGeneration: KernelGeneration418, wantStates: current},
+		"RHEL 9":            {release: "5.14.0-362.8.1.el9_3.x86_64", wantGeneration: KernelGeneration418, wantStates: current},
+		"newer than RHEL 9": {release: "6.8.0-rc3", wantGeneration: KernelGeneration418, wantStates: current},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kr, err := ParseKernelRelease(tt.release)
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", tt.release, err)
+			}
+
+			if got := kr.KernelGeneration(); got != tt.wantGeneration {
+				t.Errorf("want generation %s, got %s", tt.wantGeneration, got)
+			}
+
+			expected := kr.ExpectedProcessStates()
+			got := make([]string, 0, len(expected))
+			for _, state := range expected {
+				got = append(got, state.String())
+			}
+
+			if !slices.Equal(got, tt.wantStates) {
+				t.Errorf("\nwant states %q\ngot states  %q", tt.wantStates, got)
+			}
+		})
+	}
+}
+
+// TestUnexpectedStates asserts that processes in states not emitted by the
+// kernel release, unknown states and malformed states are reported as
+// unexpected and that the service state accounts for them.
+func TestUnexpectedStates(t *testing.T) {
+	t.Parallel()
+
+	rhel8, err := ParseKernelRelease("4.18.0-513.5.1.el8_9.x86_64")
+	if err != nil {
+		t.Fatalf("failed to parse kernel release: %v", err)
+	}
+	expected := rhel8.ExpectedProcessStates()
+
+	sleeping := Process{Name: "bash", Pid: 100, State: KernelAnyProcessStateSleeping}
+	diskSleep := Process{Name: "java", Pid: 200, State: KernelAnyProcessStateDiskSleep}
+	wakeKill := Process{Name: "nfsd", Pid: 300, State: KernelLegacyProcessStateWakeKill}
+	legacyTracing := Process{Name: "gdb", Pid: 400, State: KernelLegacyProcessStateTracingStop}
+	unknown := Process{Name: "odd", Pid: 500, State: ProcessState{Code: 'Q', Description: "queued"}}
+	malformed := Process{Name: "broken", Pid: 600, State: ProcessState{Code: 'S', Description: "slumbering"}}
+
+	warning := nagios.ServiceState{Label: nagios.StateWARNINGLabel, ExitCode: nagios.StateWARNINGExitCode}
+	critical := nagios.ServiceState{Label: nagios.StateCRITICALLabel, ExitCode: nagios.StateCRITICALExitCode}
+	ok := nagios.ServiceState{Label: nagios.StateOKLabel, ExitCode: nagios.StateOKExitCode}
+
+	tests := map[string]struct {
+		processes      Processes
+		severity       nagios.ServiceState
+		wantUnexpected []int
+		wantState      nagios.ServiceState
+	}{
+		"expected states only": {
+			processes:      Processes{sleeping},
+			severity:       warning,
+			wantUnexpected: []int{},
+			wantState:      ok,
+		},
+		"state dropped by kernel": {
+			processes:      Processes{sleeping, wakeKill},
+			severity:       warning,
+			wantUnexpected: []int{300},
+			wantState:      warning,
+		},
+		"legacy state code": {
+			processes:      Processes{sleeping, legacyTracing},
+			severity:       warning,
+			wantUnexpected: []int{400},
+			wantState:      warning,
+		},
+		"unknown and malformed states": {
+			processes:      Processes{unknown, sleeping, malformed},
+			severity:       critical,
+			wantUnexpected: []int{500, 600},
+			wantState:      critical,
+		},
+		"known problem state more severe": {
+			processes:      Processes{diskSleep, wakeKill},
+			severity:       warning,
+			wantUnexpected: []int{300},
+			wantState:      critical,
+		},
+		"unexpected state severity ignored without unexpected processes": {
+			processes:      Processes{diskSleep},
+			severity:       critical,
+			wantUnexpected: []int{},
+			wantState:      critical,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pids(tt.processes.UnexpectedStates(expected)); !slices.Equal(got, tt.wantUnexpected) {
+				t.Errorf("\nwant unexpected %v\ngot unexpected  %v", tt.wantUnexpected, got)
+			}
+
+			got := tt.processes.ServiceStateWithUnexpected(expected, tt.severity)
+			if got != tt.wantState {
+				t.Errorf("\nwant %+v\ngot  %+v", tt.wantState, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:build linux
+
+package procstate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// readKmsg reads all records currently available from the specified kernel
+// log device without blocking. Each read from the device returns a single
+// record; reading stops once no further records are available.
+//
+// NOTE: We intentionally use the syscall package directly instead of an
+// os.File value. The os package registers non-blocking descriptors with the
+// runtime poller which would cause reads to block once the end of the
+// buffer is reached.
+//
+// https://www.kernel.org/doc/Documentation/ABI/testing/dev-kmsg
+func readKmsg(device string) ([]byte, error) {
+	fd, err := syscall.Open(device, syscall.O_RDONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", device, err)
+	}
+	defer func() {
+		_ = syscall.Close(fd)
+	}()
+
+	var data bytes.Buffer
+	buf := make([]byte, kmsgRecordMaxSize)
+	for {
+		n, err := syscall.Read(fd, buf)
+		switch {
+		case errors.Is(err, syscall.EAGAIN):
+			return data.Bytes(), nil
+
+		// Records were overwritten while reading; continue with the next
+		// available record.
+		case errors.Is(err, syscall.EPIPE):
+			continue
+
+		case errors.Is(err, syscall.EINTR):
+			continue
+
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", device, err)
+
+		case n <= 0:
+			return data.Bytes(), nil
+		}
+
+		data.Write(buf[:n])
+		if buf[n-1] != '\n' {
+			data.WriteByte('\n')
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+//go:build !linux
+
+package procstate
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// readKmsg is a stub for operating systems which do not provide a kernel log
+// device.
+func readKmsg(device string) ([]byte, error) {
+	return nil, fmt.Errorf(
+		"failed to read %s on %s: %w",
+		device,
+		runtime.GOOS,
+		ErrUnsupportedOS,
+	)
+}
@@ -0,0 +1,414 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Process provides select type-safe values related to a running process (per
+// /proc) and a map of property/values in string format.
+//
+// https://man7.org/linux/man-pages/man5/proc.5.html
+// https://linux.die.net/man/5/proc
+type Process struct {
+	Name string `json:"name"`
+
+	// State is the current state of the process. See the
+	// SupportedProcessStates function for the known process states and the
+	// kernel versions which emit them.
+	State         ProcessState `json:"state"`
+	Pid           int          `json:"pid"`
+	PPid          int          `json:"ppid"`
+	Threads       int          `json:"threads"`
+	VMSwap        string       `json:"vmswap,omitempty"`
+	VMRSS         string       `json:"vmrss,omitempty"`
+	AllProperties Properties   `json:"properties,omitempty"`
+
+	// Flags is the kernel flags word for the process as recorded in the
+	// /proc/[pid]/stat file.
+	Flags uint `json:"flags"`
+
+	// StartTime is the time the process started after system boot in clock
+	// ticks as recorded in the /proc/[pid]/stat file.
+	StartTime uint64 `json:"start_time,omitempty"`
+
+	// CPUTime is the time the process has been scheduled in user and
+	// kernel mode in clock ticks as recorded in the /proc/[pid]/stat file.
+	CPUTime uint64 `json:"cpu_time,omitempty"`
+
+	// ProcDir is the /proc/[pid] directory used to collect values for the
+	// process. This path is used to retrieve additional process details on
+	// demand.
+	ProcDir string `json:"proc_dir,omitempty"`
+
+	// KernelBlockedSeconds is the number of seconds the kernel hung task
+	// detector reported the process as blocked. This value is only set if
+	// kernel log messages were evaluated and a report for the process was
+	// found.
+	KernelBlockedSeconds int `json:"kernel_blocked_seconds,omitempty"`
+}
+
+// Properties is a collection of key/value string pairs representing
+// the various properties for a running process. Each key is lowercased with
+// the original value retained as-is.
+type Properties map[string]string
+
+// ParentProcess returns the parent Process for the current Process value from
+// the specified collection or an error if one occurs.
+func (p Process) ParentProcess(processes Processes) (Process, error) {
+	parentID := p.PPid
+	for _, process := range processes {
+		if process.Pid == parentID {
+			return process, nil
+		}
+	}
+
+	return Process{}, fmt.Errorf(
+		"failed to resolve parent process: %w",
+		ErrMissingProcessEntry,
+	)
+}
+
+// VMSwapKB returns the amount of swap memory used by the process in
+// kilobytes. Zero is returned if the process is not using swap memory or the
+// value cannot be parsed.
+func (p Process) VMSwapKB() int {
+	return parseKBValue(p.VMSwap)
+}
+
+// VMRSSKB returns the resident set size of the process in kilobytes. Zero
+// is returned if the value is not available (e.g., for kernel threads) or
+// cannot be parsed.
+func (p Process) VMRSSKB() int {
+	return parseKBValue(p.VMRSS)
+}
+
+// ProcessKey uniquely identifies a process across collections. Process ID
+// values are reused by the kernel, so the process start time is used to
+// distinguish a process from an earlier process with the same ID.
+type ProcessKey struct {
+	Pid       int    `json:"pid"`
+	StartTime uint64 `json:"start_time"`
+}
+
+// Key returns the ProcessKey identifying the process.
+func (p Process) Key() ProcessKey {
+	return ProcessKey{Pid: p.Pid, StartTime: p.StartTime}
+}
+
+// StartedAt returns the time the process started given the specified system
+// boot time.
+func (p Process) StartedAt(bootTime time.Time) time.Time {
+	return bootTime.Add(ClockTicksToDuration(p.StartTime))
+}
+
+// UID returns the real user ID of the process or an error if one occurs.
+func (p Process) UID() (int, error) {
+	uidStr, ok := p.AllProperties[ProcessUIDField]
+	if !ok {
+		return -1, fmt.Errorf(
+			"process property %s: %w",
+			ProcessUIDField,
+			ErrMissingProcessPropertiesIndexEntry,
+		)
+	}
+
+	// The real, effective, saved set and filesystem UIDs are listed in that
+	// order.
+	fields := strings.Fields(uidStr)
+	if len(fields) == 0 {
+		return -1, fmt.Errorf(
+			"failed to parse value %s for property %s: %w",
+			uidStr,
+			ProcessUIDField,
+			ErrInvalidProcStatusLineValue,
+		)
+	}
+
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return -1, fmt.Errorf(
+			"failed to convert value %s for property %s as number: %w",
+			fields[0],
+			ProcessUIDField,
+			ErrInvalidProcStatusLineValue,
+		)
+	}
+
+	return n, nil
+}
+
+// Username returns the name of the user associated with the real user ID
+// of the process or an error if one occurs. Usernames are resolved once per
+// user ID and cached for subsequent calls.
+func (p Process) Username() (string, error) {
+	uid, err := p.UID()
+	if err != nil {
+		return "", err
+	}
+
+	return usernames.lookup(uid)
+}
+
+// usernames is the cache of usernames resolved for user ID values. Resolving
+// a username may require a NSS lookup (e.g., LDAP), so each user ID is only
+// resolved once instead of once per process.
+var usernames = usernameCache{
+	entries: make(map[int]usernameCacheEntry),
+}
+
+// usernameCache is a concurrency-safe cache of usernames (or lookup errors)
+// indexed by user ID.
+type usernameCache struct {
+	mu      sync.Mutex
+	entries map[int]usernameCacheEntry
+}
+
+// usernameCacheEntry is the result of resolving the username for a user ID.
+type usernameCacheEntry struct {
+	username string
+	err      error
+}
+
+// lookup returns the username associated with the specified user ID or an
+// error if one occurs. Failed lookups are also cached.
+func (c *usernameCache) lookup(uid int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[uid]; ok {
+		return entry.username, entry.err
+	}
+
+	var entry usernameCacheEntry
+	u, err := user.LookupId(strconv.Itoa(uid))
+	switch {
+	case err != nil:
+		entry.err = fmt.Errorf("failed to resolve username for uid %d: %w", uid, err)
+	default:
+		entry.username = u.Username
+	}
+	c.entries[uid] = entry
+
+	return entry.username, entry.err
+}
+
+// Cmdline returns the complete command line for the process or an error if
+// one occurs. Arguments are separated by a single space. The command line
+// is empty for kernel threads and zombie processes.
+func (p Process) Cmdline() (string, error) {
+	data, err := p.readProcFile(ProcCmdlineFilename)
+	if err != nil {
+		return "", err
+	}
+
+	data = bytes.TrimRight(data, "\x00")
+
+	return string(bytes.ReplaceAll(data, []byte{0}, []byte(" "))), nil
+}
+
+// Cgroups returns the control group paths to which the process belongs or
+// an error if one occurs.
+func (p Process) Cgroups() ([]string, error) {
+	data, err := p.readProcFile(ProcCgroupFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each entry is in hierarchy-ID:controller-list:cgroup-path format.
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	cgroups := make([]string, 0, len(lines))
+	for _, line := range lines {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		cgroups = append(cgroups, fields[2])
+	}
+
+	return cgroups, nil
+}
+
+// Wchan returns the name of the kernel function in which the process is
+// sleeping or an error if one occurs. The value is empty (or "0") for
+// running processes.
+func (p Process) Wchan() (string, error) {
+	data, err := p.readProcFile(ProcWchanFilename)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Stack returns the kernel stack trace of the process or an error if one
+// occurs. Reading the stack of a process requires elevated privileges.
+func (p Process) Stack() ([]string, error) {
+	data, err := p.readProcFile(ProcStackFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// ProcessLimit is a resource limit of a process as listed in the
+// /proc/[pid]/limits file.
+type ProcessLimit struct {
+	// Name is the name of the resource limit (e.g., "Max open files").
+	Name string
+
+	// Soft is the soft limit value (e.g., "1024" or "unlimited").
+	Soft string
+
+	// Hard is the hard limit value (e.g., "4096" or "unlimited").
+	Hard string
+
+	// Units is the unit of the limit values (e.g., "files"). This value is
+	// empty for limits without units.
+	Units string
+}
+
+// Limits returns the resource limits of the process or an error if one
+// occurs.
+func (p Process) Limits() ([]ProcessLimit, error) {
+	data, err := p.readProcFile(ProcLimitsFilename)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	// Values are aligned in fixed width columns using the positions of the
+	// column headers. Limit names contain spaces, so we cannot split lines
+	// on whitespace.
+	header := lines[0]
+	softIdx := strings.Index(header, "Soft Limit")
+	hardIdx := strings.Index(header, "Hard Limit")
+	unitsIdx := strings.Index(header, "Units")
+	if softIdx < 0 || hardIdx < softIdx || unitsIdx < hardIdx {
+		return nil, fmt.Errorf(
+			"unexpected header %q for process %d: %w",
+			header,
+			p.Pid,
+			ErrInvalidProcLimitsFormat,
+		)
+	}
+
+	column := func(line string, start int, end int) string {
+		start, end = min(start, len(line)), min(end, len(line))
+
+		return strings.TrimSpace(line[start:end])
+	}
+
+	limits := make([]ProcessLimit, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		limits = append(limits, ProcessLimit{
+			Name:  column(line, 0, softIdx),
+			Soft:  column(line, softIdx, hardIdx),
+			Hard:  column(line, hardIdx, unitsIdx),
+			Units: column(line, unitsIdx, len(line)),
+		})
+	}
+
+	return limits, nil
+}
+
+// FDCount returns the number of open file descriptors of the process or an
+// error if one occurs. Listing the file descriptors of processes owned by
+// other users requires elevated privileges.
+func (p Process) FDCount() (int, error) {
+	fdDir := filepath.Join(p.procDir(), ProcFDDirname)
+
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read directory %s: %w", fdDir, err)
+	}
+
+	return len(entries), nil
+}
+
+// parseKBValue is a helper function used to parse a memory value from a
+// /proc/[pid]/status file (e.g., "1234 kB") as a number of kilobytes. Zero
+// is returned for empty or invalid values.
+func parseKBValue(value string) int {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0
+	}
+
+	n, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
+
+// procDir is a helper function used to return the /proc/[pid] directory for
+// the process.
+func (p Process) procDir() string {
+	if p.ProcDir == "" {
+		return filepath.Join(ProcRootDir, strconv.Itoa(p.Pid))
+	}
+
+	return p.ProcDir
+}
+
+// readProcFile is a helper function used to read the specified file from
+// the /proc/[pid] directory for the process.
+func (p Process) readProcFile(filename string) ([]byte, error) {
+	qualifiedPath := filepath.Join(p.procDir(), filename)
+	data, err := os.ReadFile(filepath.Clean(qualifiedPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", qualifiedPath, err)
+	}
+
+	return data, nil
+}
+
+// IsKernelThread indicates whether the process is a kernel thread. Kernel
+// threads are identified as the kernel thread daemon (kthreadd) itself, its
+// children or any process with the PF_KTHREAD kernel flag set.
+func (p Process) IsKernelThread() bool {
+	return p.Pid == KThreaddPID ||
+		p.PPid == KThreaddPID ||
+		p.Flags&PFKThread != 0
+}
+
+// IsOKState indicates whether the process state is not in a list of known
+// problematic process states.
+func (p Process) IsOKState() bool {
+	return p.State.Severity == SeverityOK
+}
+
+// IsWarningState indicates whether the state matches known WARNING severity
+// process states.
+func (p Process) IsWarningState() bool {
+	return p.State.Severity == SeverityWarning
+}
+
+// IsCriticalState indicates whether the state matches known CRITICAL severity
+// process states.
+func (p Process) IsCriticalState() bool {
+	return p.State.Severity == SeverityCritical
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestProcessLimits asserts that resource limits are parsed from the fixed
+// width columns of a limits file, including limit names containing spaces
+// and limits without units, and that malformed files are rejected.
+func TestProcessLimits(t *testing.T) {
+	t.Parallel()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "limits", "rhel8-java.limits"))
+	if err != nil {
+		t.Fatalf("failed to read testdata file: %v", err)
+	}
+
+	tests := map[string]struct {
+		content   string
+		missing   bool
+		wantCount int
+		wantErr   error
+	}{
+		"limits file": {
+			content:   string(fixture),
+			wantCount: 16,
+		},
+		"unexpected header": {
+			content: "Max open files 1024 524288 files\n",
+			wantErr: ErrInvalidProcLimitsFormat,
+		},
+		"empty file": {
+			wantErr: ErrInvalidProcLimitsFormat,
+		},
+		"missing file": {
+			missing: true,
+			wantErr: fs.ErrNotExist,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			procDir := t.TempDir()
+			if !tt.missing {
+				if err := os.WriteFile(filepath.Join(procDir, ProcLimitsFilename), []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("failed to create limits file: %v", err)
+				}
+			}
+
+			p := Process{Name: "java", Pid: 4321, ProcDir: procDir}
+
+			limits, err := p.Limits()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error wrapping %v, got %v", tt.wantErr, err)
+			}
+
+			if len(limits) != tt.wantCount {
+				t.Fatalf("want %d limits, got %d: %+v", tt.wantCount, len(limits), limits)
+			}
+
+			if tt.wantCount == 0 {
+				return
+			}
+
+			want := map[string]ProcessLimit{
+				"Max cpu time":          {Name: "Max cpu time", Soft: "unlimited", Hard: "unlimited", Units: "seconds"},
+				"Max stack size":        {Name: "Max stack size", Soft: "8388608", Hard: "unlimited", Units: "bytes"},
+				"Max open files":        {Name: "Max open files", Soft: "1024", Hard: "524288", Units: "files"},
+				"Max realtime priority": {Name: "Max realtime priority", Soft: "0", Hard: "0"},
+				"Max realtime timeout":  {Name: "Max realtime timeout", Soft: "unlimited", Hard: "unlimited", Units: "us"},
+			}
+
+			for _, limit := range limits {
+				if w, ok := want[limit.Name]; ok && limit != w {
+					t.Errorf("\nwant %+v\ngot  %+v", w, limit)
+				}
+				delete(want, limit.Name)
+			}
+
+			if len(want) != 0 {
+				t.Errorf("limits not found: %v", want)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// Processes is a collection of Process values.
+type Processes []Process
+
+// ParentProcess returns the parent Process for the specified Process value
+// from the collection or an error if one occurs.
+func (ps Processes) ParentProcess(p Process) (Process, error) {
+	parentID := p.PPid
+	for _, processEntry := range ps {
+		if processEntry.Pid == parentID {
+			return processEntry, nil
+		}
+	}
+
+	return Process{}, fmt.Errorf(
+		"failed to resolve parent process: %w",
+		ErrMissingProcessEntry,
+	)
+}
+
+// Exclude returns Process values from the collection which are not in the
+// specified set. Process values are matched by ProcessKey (process ID and
+// start time) so that a process reusing the ID of an excluded process is
+// retained. If the specified set is empty all Process values in the
+// collection are returned.
+func (ps Processes) Exclude(exclude ...Process) Processes {
+
+	// Build index of key values for the set we are to exclude.
+	excludeKeys := make(map[ProcessKey]struct{}, len(exclude))
+	for _, p := range exclude {
+		excludeKeys[p.Key()] = struct{}{}
+	}
+
+	remaining := make(Processes, 0)
+	for _, p := range ps {
+		_, excluded := excludeKeys[p.Key()]
+		if !excluded {
+			remaining = append(remaining, p)
+		}
+	}
+
+	return remaining
+}
+
+// MyProcess returns the Process value from the collection which matches the
+// current process ID value of the tool executing this code. A zero value
+// Process is returned if a match is not found for the current process ID
+// value.
+func (ps Processes) MyProcess() Process {
+	myPID := os.Getpid()
+	for _, p := range ps {
+		if p.Pid == myPID {
+			return p
+		}
+	}
+
+	return Process{}
+}
+
+// ExcludeMyPID returns Process values from the collection which do not match
+// the current process ID value of the tool executing this code.
+func (ps Processes) ExcludeMyPID() Processes {
+	myPID := os.Getpid()
+	remaining := make(Processes, 0)
+	for _, p := range ps {
+		if p.Pid != myPID {
+			remaining = append(remaining, p)
+		}
+	}
+
+	return remaining
+}
+
+// Children returns each Process from the collection which is a direct child
+// of the specified Process. The returned collection may be empty if no child
+// processes are found.
+func (ps Processes) Children(parent Process) Processes {
+	return ps.Filter(func(p Process) bool {
+		return p.PPid == parent.Pid && p.Pid != parent.Pid
+	})
+}
+
+// Ancestors returns the chain of parent processes from the collection for
+// the specified Process, nearest parent first. The init process (PID 1) and
+// kernel thread daemon (PID 2) are not included in the chain. The returned
+// collection may be empty if no parent processes are found.
+func (ps Processes) Ancestors(p Process) Processes {
+	ancestors := make(Processes, 0)
+	seen := map[int]struct{}{p.Pid: {}}
+
+	current := p
+	for {
+		parent, err := ps.ParentProcess(current)
+		if err != nil || parent.Pid <= KThreaddPID {
+			return ancestors
+		}
+
+		// Guard against (unlikely) cycles caused by PID reuse between
+		// reading status files.
+		if _, ok := seen[parent.Pid]; ok {
+			return ancestors
+		}
+		seen[parent.Pid] = struct{}{}
+
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+}
+
+// MyAncestors returns the chain of parent processes from the collection for
+// the process of the tool executing this code, nearest parent first. This
+// usually includes the monitoring agent (e.g., NRPE) and any wrapper shell
+// used to execute the tool. The returned collection may be empty if the
+// current process is not found in the collection.
+func (ps Processes) MyAncestors() Processes {
+	myProcess := ps.MyProcess()
+	if myProcess.Pid == 0 {
+		return Processes{}
+	}
+
+	return ps.Ancestors(myProcess)
+}
+
+// ExcludeMyAncestry returns Process values from the collection which are not
+// the process of the tool executing this code or one of its ancestors (e.g.,
+// the monitoring agent or a wrapper shell). If specified, the other child
+// processes of the direct parent of the tool (the agent process) are also
+// excluded; this covers sibling processes of the same agent such as zombies
+// from previous plugin invocations which have not yet been reaped. Children
+// of more distant ancestors (e.g., sshd or a service manager) are retained.
+func (ps Processes) ExcludeMyAncestry(includeSiblings bool) Processes {
+	return ps.excludeAncestry(ps.MyProcess(), includeSiblings).ExcludeMyPID()
+}
+
+// excludeAncestry returns Process values from the collection which are not
+// the specified Process or one of its ancestors. If specified, the other
+// child processes of the direct parent of the specified Process are also
+// excluded.
+func (ps Processes) excludeAncestry(p Process, includeSiblings bool) Processes {
+	if p.Pid == 0 {
+		return ps
+	}
+
+	ancestors := ps.Ancestors(p)
+
+	exclude := make(Processes, 0, len(ancestors)+1)
+	exclude = append(exclude, p)
+	exclude = append(exclude, ancestors...)
+
+	if includeSiblings && len(ancestors) > 0 {
+		exclude = append(exclude, ps.Children(ancestors[0])...)
+	}
+
+	return ps.Exclude(exclude...)
+}
+
+// KernelThreads returns each Process from the collection which is a kernel
+// thread. The returned collection may be empty if no kernel threads are
+// found.
+func (ps Processes) KernelThreads() Processes {
+	return ps.Filter(IsKernelThread)
+}
+
+// Userland returns each Process from the collection which is not a kernel
+// thread. The returned collection may be empty if only kernel threads are
+// found.
+func (ps Processes) Userland() Processes {
+	return ps.Filter(Not(IsKernelThread))
+}
+
+// StateCount is a helper method used to indicate how many processes in the
+// collection are in the specified state. Process states are compared using
+// their normalized category; processes in an equivalent state for a
+// different kernel version (e.g., "T (tracing stop)" and "t (tracing
+// stop)") are included.
+func (ps Processes) StateCount(state ProcessState) int {
+	return ps.CountFunc(ByState(state))
+}
+
+// Count returns the number of Process entries in the collection.
+func (ps Processes) Count() int {
+	return len(ps)
+}
+
+// State returns each Process from the collection that are in the specified
+// state. Process states are compared using their normalized category. The
+// returned collection may be empty if no processes are in the requested
+// state.
+func (ps Processes) State(state ProcessState) Processes {
+	return ps.Category(state.Category)
+}
+
+// States returns each Process from the collection that are in the specified
+// states. Process states are compared using their normalized category. The
+// returned collection is empty if no processes are in the requested states.
+func (ps Processes) States(states []ProcessState) Processes {
+
+	// If an empty set of states was specified, an empty collection is
+	// provided.
+	if len(states) == 0 {
+		return Processes{}
+	}
+
+	categories := make([]StateCategory, 0, len(states))
+	seen := make(map[StateCategory]struct{}, len(states))
+	for _, state := range states {
+		if _, ok := seen[state.Category]; ok {
+			continue
+		}
+		seen[state.Category] = struct{}{}
+		categories = append(categories, state.Category)
+	}
+
+	processes := make(Processes, 0)
+	for _, category := range categories {
+		processes = append(processes, ps.Category(category)...)
+	}
+
+	return processes
+}
+
+// Category returns each Process from the collection with a state in the
+// specified state category. The returned collection may be empty if no
+// processes are in the requested state category.
+func (ps Processes) Category(category StateCategory) Processes {
+	return ps.Filter(ByCategory(category))
+}
+
+// CategoryCount returns the number of processes from the collection with a
+// state in the specified state category.
+func (ps Processes) CategoryCount(category StateCategory) int {
+	return ps.CountFunc(ByCategory(category))
+}
+
+// SummaryOneLine returns a one line summary of all processes.
+func (ps Processes) SummaryOneLine() string {
+	return ps.Summary(SummaryOptions{}).OneLine()
+}
+
+// SummaryList returns a slice of process summary items.
+func (ps Processes) SummaryList() []string {
+	return ps.Summary(SummaryOptions{}).StateList()
+}
+
+// IsOKState indicates whether all items in the collection were evaluated to
+// an OK state.
+func (ps Processes) IsOKState() bool {
+	return !ps.Any(Not(BySeverity(SeverityOK)))
+}
+
+// NumOKState indicates how many items in the collection were evaluated to
+// an OK state.
+func (ps Processes) NumOKState() int {
+	return ps.CountFunc(BySeverity(SeverityOK))
+}
+
+// HasCriticalState indicates whether any items in the collection were
+// evaluated to a CRITICAL state.
+func (ps Processes) HasCriticalState() bool {
+	return ps.Any(BySeverity(SeverityCritical))
+}
+
+// NumCriticalState indicates how many items in the collection were evaluated
+// to a CRITICAL state.
+func (ps Processes) NumCriticalState() int {
+	return ps.CountFunc(BySeverity(SeverityCritical))
+}
+
+// HasWarningState indicates whether any items in the collection were
+// evaluated to a WARNING state.
+func (ps Processes) HasWarningState() bool {
+	return ps.Any(BySeverity(SeverityWarning))
+}
+
+// NumWarningState indicates how many items in the collection were evaluated
+// to a WARNING state.
+func (ps Processes) NumWarningState() int {
+	return ps.CountFunc(BySeverity(SeverityWarning))
+}
+
+// ServiceState returns the appropriate Service Check Status label and exit
+// code for the collection's evaluation results.
+func (ps Processes) ServiceState() nagios.ServiceState {
+	var stateLabel string
+	var stateExitCode int
+
+	switch {
+	case ps.HasCriticalState():
+		stateLabel = nagios.StateCRITICALLabel
+		stateExitCode = nagios.StateCRITICALExitCode
+	case ps.HasWarningState():
+		stateLabel = nagios.StateWARNINGLabel
+		stateExitCode = nagios.StateWARNINGExitCode
+	case ps.IsOKState():
+		stateLabel = nagios.StateOKLabel
+		stateExitCode = nagios.StateOKExitCode
+	default:
+		stateLabel = nagios.StateUNKNOWNLabel
+		stateExitCode = nagios.StateUNKNOWNExitCode
+	}
+
+	return nagios.ServiceState{
+		Label:    stateLabel,
+		ExitCode: stateExitCode,
+	}
+}
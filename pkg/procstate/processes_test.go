@@ -0,0 +1,105 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestExcludeAncestry asserts that the ancestry of a process is excluded
+// and that only the other children of its direct parent (the agent) are
+// excluded when requested. Unrelated descendants of more distant ancestors
+// (e.g., systemd or sshd) must still be evaluated.
+func TestExcludeAncestry(t *testing.T) {
+	t.Parallel()
+
+	// systemd (1)
+	// ├── sshd (500)
+	// │   └── sshd (501)
+	// │       └── bash (502)
+	// │           └── sleep (503) [D]
+	// ├── crond (600)
+	// │   └── backup.sh (601) [D]
+	// └── nrpe (700)
+	//     ├── sh (701)
+	//     │   └── check_process (702)
+	//     ├── check_process (703) [Z]
+	//     └── check_disk (704)
+	// kthreadd (2)
+	// └── kworker/0:1 (3) [D]
+	processes := Processes{
+		{Name: "systemd", Pid: 1, PPid: 0, State: KernelAnyProcessStateSleeping},
+		{Name: "kthreadd", Pid: 2, PPid: 0, State: KernelAnyProcessStateSleeping},
+		{Name: "kworker/0:1", Pid: 3, PPid: 2, State: KernelAnyProcessStateDiskSleep},
+		{Name: "sshd", Pid: 500, PPid: 1, State: KernelAnyProcessStateSleeping},
+		{Name: "sshd", Pid: 501, PPid: 500, State: KernelAnyProcessStateSleeping},
+		{Name: "bash", Pid: 502, PPid: 501, State: KernelAnyProcessStateSleeping},
+		{Name: "sleep", Pid: 503, PPid: 502, State: KernelAnyProcessStateDiskSleep},
+		{Name: "crond", Pid: 600, PPid: 1, State: KernelAnyProcessStateSleeping},
+		{Name: "backup.sh", Pid: 601, PPid: 600, State: KernelAnyProcessStateDiskSleep},
+		{Name: "nrpe", Pid: 700, PPid: 1, State: KernelAnyProcessStateSleeping},
+		{Name: "sh", Pid: 701, PPid: 700, State: KernelAnyProcessStateSleeping},
+		{Name: "check_process", Pid: 702, PPid: 701, State: KernelAnyProcessStateRunning},
+		{Name: "check_process", Pid: 703, PPid: 700, State: KernelAnyProcessStateZombie},
+		{Name: "check_disk", Pid: 704, PPid: 700, State: KernelAnyProcessStateRunning},
+	}
+
+	tests := map[string]struct {
+		me              int
+		includeSiblings bool
+		wantExcluded    []int
+	}{
+		"ancestors only": {
+			me:           702,
+			wantExcluded: []int{700, 701, 702},
+		},
+		"ancestors and siblings under wrapper shell": {
+			me:              702,
+			includeSiblings: true,
+			wantExcluded:    []int{700, 701, 702},
+		},
+		"ancestors and siblings under agent": {
+			me:              701,
+			includeSiblings: true,
+			wantExcluded:    []int{700, 701, 703, 704},
+		},
+		"process not found": {
+			me:              999,
+			includeSiblings: true,
+			wantExcluded:    []int{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			me := processes.Filter(ByPid(tt.me))
+			var myProcess Process
+			if len(me) > 0 {
+				myProcess = me[0]
+			}
+
+			remaining := processes.excludeAncestry(myProcess, tt.includeSiblings)
+
+			excluded := pids(processes.Exclude(remaining...))
+			if !slices.Equal(excluded, tt.wantExcluded) {
+				t.Errorf("\nwant excluded %v\ngot excluded  %v", tt.wantExcluded, excluded)
+			}
+
+			// Unrelated grandchildren of systemd and the children of kthreadd
+			// are always evaluated.
+			for _, pid := range []int{1, 3, 503, 601} {
+				if !slices.Contains(pids(remaining), pid) {
+					t.Errorf("unrelated process %d was excluded", pid)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// getProcDirsCount is a small helper function to calculate the number of
+// directories from a given collection with valid process (ID pattern) names.
+func getProcDirsCount(dirEntries []fs.DirEntry, re *regexp.Regexp) int {
+	var ctr int
+	for _, path := range dirEntries {
+		if path.IsDir() && re.MatchString(path.Name()) {
+			ctr++
+		}
+	}
+	return ctr
+}
+
+// GetProcDirs evaluates the given base path (usually "/proc") for known
+// process directories within the proc filesystem and returns an unqualified
+// list of all valid matches.
+func GetProcDirs(path string) ([]string, error) {
+	// Will panic if the regex fails to compile. Because the regex value is
+	// fixed and unlikely to be modified this should be a safe decision.
+	re := regexp.MustCompile(ProcDirRegex)
+
+	dirEntries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to evaluate processes using proc path %s: %w",
+			path,
+			err,
+		)
+	}
+
+	procDirsCount := getProcDirsCount(dirEntries, re)
+	procDirs := make([]string, 0, procDirsCount)
+	for _, path := range dirEntries {
+		if !path.IsDir() {
+			continue
+		}
+
+		// If the subdirectory of /proc matches our regular expression
+		if re.MatchString(path.Name()) {
+			procDirs = append(procDirs, path.Name())
+		}
+	}
+
+	return procDirs, nil
+}
+
+// ParseProcStatusFile parses a given /proc/[pid]/status file and returns a
+// Process value representing the status of a process.
+func ParseProcStatusFile(filename string) (Process, error) {
+	data, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return Process{},
+			fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	r := bytes.NewReader(data)
+	p, err := getProcessProperties(r)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Path = filename
+
+			return Process{}, err
+		}
+
+		return Process{}, fmt.Errorf(
+			"failed to obtain properties for process from file %s: %w",
+			filename,
+			err,
+		)
+	}
+
+	return p, nil
+}
+
+// scanStatusLines is a bufio.SplitFunc which splits status file content on
+// newline characters. Unlike bufio.ScanLines, a trailing carriage return is
+// retained as it may be a valid part of a process name.
+func scanStatusLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// getProcessProperties is responsible for processing a status file's content
+// (exposed as an io.Reader) and generating a Process value that represents
+// common values (e.g., Name, State, Pid) and a full index of all process
+// properties found.
+func getProcessProperties(r io.Reader) (Process, error) {
+
+	// Setup a new scanner and process each line splitting on ':' character.
+	//
+	// Then, trim the whitespace from column 1 and column 2.
+	//
+	// Create a lowercased version of column one and use as a map key. Store
+	// column two as value in the map.
+	//
+	// After we populate the map, we retrieve specific values *from* the map
+	// and convert (where needed) to type safe values for later direct use.
+	//
+	// Of interest are these properties:
+	//
+	// Name
+	// State
+	// Pid
+	// PPid
+	// Threads (could be useful for determining if something is running away?)
+	// VMSwap
+	//
+	// Other values are stored as-is for later use if needed.
+
+	var process Process
+	process.AllProperties = make(Properties)
+
+	// Track the line number for each property so that errors encountered
+	// when converting property values may refer to the offending line.
+	lineNumbers := make(map[string]int)
+	var lineNum int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanStatusLines)
+	for scanner.Scan() {
+		lineNum++
+
+		line := scanner.Text()
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		// Blank lines have been skipped, so anything remaining should be in
+		// the expected key:value format. We might only have the key field;
+		// this is acceptable.
+		//
+		// NOTE: We split on the first colon only as some process names
+		// may contain colons (e.g., 'flush-253:0').
+		rawKey, rawValue, _ := strings.Cut(line, ":")
+
+		key := strings.ToLower(strings.TrimSpace(rawKey))
+		if key == "" {
+			return Process{}, &ParseError{
+				Line:  lineNum,
+				Value: line,
+				Err:   ErrInvalidProcStatusLineFormat,
+			}
+		}
+
+		var value string
+		switch key {
+		case ProcessNameField:
+			// The kernel separates the name from the key using a single tab
+			// character. Process names may begin or end with whitespace
+			// (e.g., 'Web Content ') so we retain the remainder of the value
+			// as-is. Newline and backslash characters within process names
+			// are escaped by the kernel (e.g., 'a\nb'); the escaped form is
+			// retained so that the name is safe to include in output.
+			value = strings.TrimPrefix(rawValue, "\t")
+		default:
+			value = strings.TrimSpace(rawValue)
+		}
+
+		process.AllProperties[key] = value
+		lineNumbers[key] = lineNum
+
+	}
+
+	// Handle any errors from scanning the status file.
+	if err := scanner.Err(); err != nil {
+		return Process{}, err
+	}
+
+	// Finally, let's populate the type-specific fields of our Process entry.
+	if err := process.setProcessPropsWithLines(lineNumbers); err != nil {
+		return Process{}, err
+	}
+
+	return process, nil
+
+}
+
+// setProcessPropsWithLines sets the "primary" fields of the Process from the
+// properties index. If an error occurs for a specific property the given
+// index of property line numbers is used to record the offending line.
+func (p *Process) setProcessPropsWithLines(lineNumbers map[string]int) error {
+	if err := p.setProcessProps(); err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) && parseErr.Key != "" {
+			parseErr.Line = lineNumbers[parseErr.Key]
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// FromProcDirs evaluates the status file within a given list of /proc/[pid]
+// directories and returns either a collection of Process values or an error
+// if one occurs.
+func FromProcDirs(procDirs []string) (Processes, error) {
+	return CollectSeq(SeqFromProcDirs(procDirs))
+}
+
+// processFromProcDir evaluates the status and stat files within the given
+// qualified /proc/[pid] directory and returns a Process value. If specified,
+// all status file properties are retained, otherwise only those listed by
+// EvaluatedProperties are retained. A false boolean value is returned
+// (without an error) if the process exited before evaluation was complete.
+func processFromProcDir(qualifiedProcDir string, allProperties bool) (Process, bool, error) {
+	parseStatus := ParseProcStatusFileSelective
+	if allProperties {
+		parseStatus = ParseProcStatusFile
+	}
+
+	qualifiedPath := filepath.Join(qualifiedProcDir, ProcStatusFilename)
+	p, err := parseStatus(qualifiedPath)
+	if err != nil {
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// open /proc/22991/status: no such file or directory
+			//
+			// This is commonly encountered for short lived processes; we
+			// see the process directory when initially listing process
+			// directories within the proc filesystem, but then don't find
+			// it again when we attempt to parse the status file within
+			// each subdirectory. In order to prevent erroring out over
+			// short-lived processes we skip to the next item to evaluate.
+			return Process{}, false, nil
+
+		default:
+			return Process{}, false, fmt.Errorf(
+				"fatal error encountered processing proc status file %s: %w",
+				qualifiedPath,
+				err,
+			)
+		}
+	}
+
+	statPath := filepath.Join(qualifiedProcDir, ProcStatFilename)
+	stat, err := ParseProcStatFile(statPath)
+	if err != nil {
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// The process exited after reading the status file.
+			return Process{}, false, nil
+
+		default:
+			return Process{}, false, fmt.Errorf(
+				"fatal error encountered processing proc stat file %s: %w",
+				statPath,
+				err,
+			)
+		}
+	}
+
+	p.Flags = stat.Flags
+	p.StartTime = stat.StartTime
+	p.CPUTime = stat.UTime + stat.STime
+	p.ProcDir = qualifiedProcDir
+
+	return p, true, nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// statusTemplateFile is the status file used as a template for generated
+// status file content.
+const statusTemplateFile string = "testdata/status/rhel9-name-with-space.status"
+
+// addSeedFiles adds the content of each file matching the given pattern to
+// the seed corpus for a fuzz target.
+func addSeedFiles(f *testing.F, pattern string) {
+	f.Helper()
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		f.Fatalf("failed to list seed corpus files: %v", err)
+	}
+
+	if len(files) == 0 {
+		f.Fatalf("no seed corpus files found matching %s", pattern)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			f.Fatalf("failed to read seed corpus file: %v", err)
+		}
+		f.Add(data)
+	}
+}
+
+// escapeProcName escapes a process name in the same way as the kernel does
+// for the Name field of a /proc/[pid]/status file.
+func escapeProcName(name string) string {
+	r := strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+	return r.Replace(name)
+}
+
+// FuzzParseStatus asserts that parsing arbitrary status file content does
+// not panic and that any returned parse errors wrap a known error.
+func FuzzParseStatus(f *testing.F) {
+	addSeedFiles(f, "testdata/status/*.status")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p, err := getProcessProperties(bytes.NewReader(data))
+		if err != nil {
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) && parseErr.Unwrap() == nil {
+				t.Errorf("parse error %q does not wrap an error", err)
+			}
+
+			return
+		}
+
+		if p.AllProperties == nil {
+			t.Errorf("properties index not set for successfully parsed content")
+		}
+
+		if p.Name != p.AllProperties[ProcessNameField] {
+			t.Errorf(
+				"name field %q does not match properties index value %q",
+				p.Name,
+				p.AllProperties[ProcessNameField],
+			)
+		}
+
+		// Aside from the minimum number of retained properties, the
+		// selective parser is expected to succeed for content parsed by the
+		// full parser and provide the same values.
+		selective, err := parseStatusSelective(data)
+		switch {
+		case errors.Is(err, ErrInvalidProcessPropertiesIndexCount):
+			return
+		case err != nil:
+			t.Fatalf("selective parser failed for content parsed by full parser: %v", err)
+		}
+
+		if selective.Name != p.Name ||
+			selective.State.String() != p.State.String() ||
+			selective.Pid != p.Pid ||
+			selective.PPid != p.PPid ||
+			selective.Threads != p.Threads ||
+			selective.VMSwap != p.VMSwap {
+			t.Errorf("\nfull parser: %+v\nselective parser: %+v", p, selective)
+		}
+	})
+}
+
+// FuzzParseStatusName asserts that process names are retained as-is
+// (escaped as emitted by the kernel) when parsing status file content.
+func FuzzParseStatusName(f *testing.F) {
+	template, err := os.ReadFile(statusTemplateFile)
+	if err != nil {
+		f.Fatalf("failed to read status template file: %v", err)
+	}
+
+	_, remainder, found := bytes.Cut(template, []byte("\n"))
+	if !found {
+		f.Fatalf("status template file %s is invalid", statusTemplateFile)
+	}
+
+	for _, name := range []string{
+		"bash",
+		"flush-253:0",
+		"kworker/u8:2-events_unbound",
+		"Web Content",
+		" leading space",
+		"trailing space ",
+		"\ttab",
+		"carriage return\r",
+		"new\nline",
+		`back\slash`,
+		"State:\tZ (zombie)",
+		"(sd-pam)",
+		"",
+	} {
+		f.Add(name)
+	}
+
+	f.Fuzz(func(t *testing.T, name string) {
+		// Process names are limited in length by the kernel.
+		if len(name) > 64 {
+			t.Skip()
+		}
+
+		escaped := escapeProcName(name)
+
+		var content bytes.Buffer
+		content.WriteString("Name:\t" + escaped + "\n")
+		content.Write(remainder)
+
+		p, err := getProcessProperties(&content)
+		if err != nil {
+			t.Fatalf("failed to parse status content for name %q: %v", name, err)
+		}
+
+		if p.Name != escaped {
+			t.Errorf("\nwant name %q\ngot name %q", escaped, p.Name)
+		}
+
+		if p.Pid != 7702 {
+			t.Errorf("want pid %d, got %d", 7702, p.Pid)
+		}
+	})
+}
+
+// TestParseProcStatusFileSelectiveLargeFile asserts that a status file
+// larger than the largest pooled buffer is read completely and that status
+// files are parsed as usual once the grown buffer has been discarded.
+func TestParseProcStatusFileSelectiveLargeFile(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(statusTemplateFile)
+	if err != nil {
+		t.Fatalf("failed to read status file: %v", err)
+	}
+
+	// Pad the status file with properties which are not retained.
+	var padded bytes.Buffer
+	padded.Write(data)
+	for i := 0; padded.Len() <= statusBufferMaxSize; i++ {
+		fmt.Fprintf(&padded, "Padding%d:\t%s\n", i, strings.Repeat("x", 64))
+	}
+
+	largeFile := filepath.Join(t.TempDir(), ProcStatusFilename)
+	if err := os.WriteFile(largeFile, padded.Bytes(), 0o600); err != nil {
+		t.Fatalf("failed to create status file: %v", err)
+	}
+
+	want, err := ParseProcStatusFileSelective(statusTemplateFile)
+	if err != nil {
+		t.Fatalf("failed to parse status file: %v", err)
+	}
+
+	got, err := ParseProcStatusFileSelective(largeFile)
+	if err != nil {
+		t.Fatalf("failed to parse large status file: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, got)
+	}
+
+	got, err = ParseProcStatusFileSelective(statusTemplateFile)
+	if err != nil {
+		t.Fatalf("failed to parse status file after large status file: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, got)
+	}
+}
+
+// BenchmarkGetProcessProperties measures parsing status file content while
+// retaining all properties.
+func BenchmarkGetProcessProperties(b *testing.B) {
+	data, err := os.ReadFile(statusTemplateFile)
+	if err != nil {
+		b.Fatalf("failed to read status file: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := getProcessProperties(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseStatusSelective measures parsing status file content while
+// retaining only evaluated properties.
+func BenchmarkParseStatusSelective(b *testing.B) {
+	data, err := os.ReadFile(statusTemplateFile)
+	if err != nil {
+		b.Fatalf("failed to read status file: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := parseStatusSelective(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseProcStatusFile measures reading and parsing a status file
+// while retaining all properties.
+func BenchmarkParseProcStatusFile(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, err := ParseProcStatusFile(statusTemplateFile); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseProcStatusFileSelective measures reading and parsing a
+// status file while retaining only evaluated properties.
+func BenchmarkParseProcStatusFileSelective(b *testing.B) {
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, err := ParseProcStatusFileSelective(statusTemplateFile); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
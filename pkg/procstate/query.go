@@ -0,0 +1,385 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"cmp"
+	"os/user"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Predicate reports whether a Process matches some criteria. Predicates are
+// used with the Filter method of a Processes collection and may be combined
+// using the And, Or and Not functions.
+type Predicate func(Process) bool
+
+// CompareFunc compares two Process values and returns a negative number if
+// a sorts before b, a positive number if a sorts after b and zero if the
+// order of the values does not matter.
+type CompareFunc func(a Process, b Process) int
+
+// Filter returns each Process from the collection for which the specified
+// predicate function returns true. The returned collection may be empty if
+// no processes match.
+func (ps Processes) Filter(pred func(Process) bool) Processes {
+	processes := make(Processes, 0)
+	for _, p := range ps {
+		if pred(p) {
+			processes = append(processes, p)
+		}
+	}
+
+	return processes
+}
+
+// Any indicates whether the specified predicate function returns true for
+// any Process in the collection.
+func (ps Processes) Any(pred func(Process) bool) bool {
+	return slices.ContainsFunc(ps, pred)
+}
+
+// CountFunc returns the number of Process values in the collection for
+// which the specified predicate function returns true.
+func (ps Processes) CountFunc(pred func(Process) bool) int {
+	var ctr int
+	for _, p := range ps {
+		if pred(p) {
+			ctr++
+		}
+	}
+
+	return ctr
+}
+
+// GroupBy returns an index of key value to the collection of Process values
+// with that key as returned by the specified key function. The order of
+// Process values in each group is retained from the original collection.
+func (ps Processes) GroupBy(key func(Process) string) map[string]Processes {
+	groups := make(map[string]Processes)
+	for _, p := range ps {
+		k := key(p)
+		groups[k] = append(groups[k], p)
+	}
+
+	return groups
+}
+
+// StateGroup is a collection of Process values sharing the same process
+// state.
+type StateGroup struct {
+	State     ProcessState
+	Processes Processes
+}
+
+// GroupByState returns the Process values of the collection grouped by
+// process state. Groups are ordered by severity (most severe first), then by
+// the order of the known process state table and finally by state value,
+// providing a stable order between collections. The order of Process values
+// in each group is retained from the original collection.
+func (ps Processes) GroupByState() []StateGroup {
+	index := ps.GroupBy(func(p Process) string {
+		return p.State.String()
+	})
+
+	groups := make([]StateGroup, 0, len(index))
+	for _, processes := range index {
+		groups = append(groups, StateGroup{
+			State:     processes[0].State,
+			Processes: processes,
+		})
+	}
+
+	slices.SortFunc(groups, func(a StateGroup, b StateGroup) int {
+		return cmp.Or(
+			CompareBySeverity(a.Processes[0], b.Processes[0]),
+			CompareByState(a.Processes[0], b.Processes[0]),
+			strings.Compare(a.State.String(), b.State.String()),
+		)
+	})
+
+	return groups
+}
+
+// SortBy returns a sorted copy of the collection. Process values are
+// compared using each of the specified comparison functions in turn until a
+// difference is found. The sort is stable; the original order is retained
+// for equal Process values.
+func (ps Processes) SortBy(cmps ...CompareFunc) Processes {
+	sorted := slices.Clone(ps)
+	slices.SortStableFunc(sorted, func(a Process, b Process) int {
+		for _, fn := range cmps {
+			if c := fn(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	return sorted
+}
+
+// TopN returns the first n Process values of the collection after sorting
+// using the specified comparison functions. If n is larger than the
+// collection size the full (sorted) collection is returned.
+func (ps Processes) TopN(n int, cmps ...CompareFunc) Processes {
+	sorted := ps.SortBy(cmps...)
+	if n < 0 || n >= len(sorted) {
+		return sorted
+	}
+
+	return sorted[:n]
+}
+
+// And returns a Predicate which matches a Process if all of the specified
+// predicates match. If no predicates are specified all processes match.
+func And(preds ...Predicate) Predicate {
+	return func(p Process) bool {
+		for _, pred := range preds {
+			if !pred(p) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Predicate which matches a Process if any of the specified
+// predicates match. If no predicates are specified no processes match.
+func Or(preds ...Predicate) Predicate {
+	return func(p Process) bool {
+		for _, pred := range preds {
+			if pred(p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate which matches a Process if the specified
+// predicate does not match.
+func Not(pred Predicate) Predicate {
+	return func(p Process) bool {
+		return !pred(p)
+	}
+}
+
+// ByName returns a Predicate which matches a Process with any of the
+// specified names. Matches are case-sensitive.
+func ByName(names ...string) Predicate {
+	return func(p Process) bool {
+		return slices.Contains(names, p.Name)
+	}
+}
+
+// ByNameRegex returns a Predicate which matches a Process with a name
+// matching any of the specified regular expressions.
+func ByNameRegex(res ...*regexp.Regexp) Predicate {
+	return func(p Process) bool {
+		for _, re := range res {
+			if re.MatchString(p.Name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByCmdlineRegex returns a Predicate which matches a Process with a full
+// command line matching any of the specified regular expressions. Processes
+// for which the command line cannot be retrieved do not match.
+func ByCmdlineRegex(res ...*regexp.Regexp) Predicate {
+	return func(p Process) bool {
+		cmdline, err := p.Cmdline()
+		if err != nil {
+			return false
+		}
+
+		for _, re := range res {
+			if re.MatchString(cmdline) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByUser returns a Predicate which matches a Process with a real user ID
+// matching any of the specified usernames or numeric user ID values.
+// Usernames are resolved to user ID values once when the Predicate is
+// created; usernames which cannot be resolved do not match any process.
+func ByUser(users ...string) Predicate {
+	uids := make([]int, 0, len(users))
+	for _, name := range users {
+		if uid, err := strconv.Atoi(name); err == nil {
+			uids = append(uids, uid)
+
+			continue
+		}
+
+		u, err := user.Lookup(name)
+		if err != nil {
+			continue
+		}
+
+		if uid, err := strconv.Atoi(u.Uid); err == nil {
+			uids = append(uids, uid)
+		}
+	}
+
+	return func(p Process) bool {
+		uid, err := p.UID()
+
+		return err == nil && slices.Contains(uids, uid)
+	}
+}
+
+// ByState returns a Predicate which matches a Process in any of the
+// specified states. Process states are compared using their normalized
+// category.
+func ByState(states ...ProcessState) Predicate {
+	return func(p Process) bool {
+		for _, state := range states {
+			if p.State.Equal(state) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByStateCode returns a Predicate which matches a Process in a state with
+// any of the specified single letter state codes (e.g., 'D'). Unlike
+// ByState, codes are compared as-is; 'T' (stopped) and 't' (tracing stop)
+// are distinct codes.
+func ByStateCode(codes ...byte) Predicate {
+	return func(p Process) bool {
+		return slices.Contains(codes, p.State.Code)
+	}
+}
+
+// ByCategory returns a Predicate which matches a Process with a state in any
+// of the specified state categories.
+func ByCategory(categories ...StateCategory) Predicate {
+	return func(p Process) bool {
+		return slices.Contains(categories, p.State.Category)
+	}
+}
+
+// BySeverity returns a Predicate which matches a Process with a state of
+// any of the specified severities.
+func BySeverity(severities ...Severity) Predicate {
+	return func(p Process) bool {
+		return slices.Contains(severities, p.State.Severity)
+	}
+}
+
+// ByPid returns a Predicate which matches a Process with any of the
+// specified process ID values.
+func ByPid(pids ...int) Predicate {
+	return func(p Process) bool {
+		return slices.Contains(pids, p.Pid)
+	}
+}
+
+// ByParent returns a Predicate which matches a Process with any of the
+// specified parent process ID values.
+func ByParent(ppids ...int) Predicate {
+	return func(p Process) bool {
+		return slices.Contains(ppids, p.PPid)
+	}
+}
+
+// ByParentName returns a Predicate which matches a Process with a parent
+// process (resolved from the specified collection) with any of the
+// specified names.
+func ByParentName(ps Processes, names ...string) Predicate {
+	return func(p Process) bool {
+		parent, err := ps.ParentProcess(p)
+		if err != nil {
+			return false
+		}
+
+		return slices.Contains(names, parent.Name)
+	}
+}
+
+// ByCgroup returns a Predicate which matches a Process with any cgroup path
+// containing any of the specified values. Processes for which the cgroup
+// paths cannot be retrieved do not match.
+func ByCgroup(patterns ...string) Predicate {
+	return func(p Process) bool {
+		cgroups, err := p.Cgroups()
+		if err != nil {
+			return false
+		}
+
+		for _, cgroup := range cgroups {
+			for _, pattern := range patterns {
+				if strings.Contains(cgroup, pattern) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// IsKernelThread is a Predicate which matches a Process which is a kernel
+// thread.
+func IsKernelThread(p Process) bool {
+	return p.IsKernelThread()
+}
+
+// Reverse returns a CompareFunc which reverses the order of the specified
+// comparison function.
+func Reverse(fn CompareFunc) CompareFunc {
+	return func(a Process, b Process) int {
+		return fn(b, a)
+	}
+}
+
+// CompareByPid compares Process values by process ID.
+func CompareByPid(a Process, b Process) int {
+	return cmp.Compare(a.Pid, b.Pid)
+}
+
+// CompareByPPid compares Process values by parent process ID.
+func CompareByPPid(a Process, b Process) int {
+	return cmp.Compare(a.PPid, b.PPid)
+}
+
+// CompareByName compares Process values by name.
+func CompareByName(a Process, b Process) int {
+	return strings.Compare(a.Name, b.Name)
+}
+
+// CompareByState compares Process values by state using the order of the
+// known process state table.
+func CompareByState(a Process, b Process) int {
+	return cmp.Compare(a.State.rank(), b.State.rank())
+}
+
+// CompareBySeverity compares Process values by state severity, most severe
+// first.
+func CompareBySeverity(a Process, b Process) int {
+	return cmp.Compare(b.State.Severity, a.State.Severity)
+}
+
+// CompareByThreads compares Process values by number of threads.
+func CompareByThreads(a Process, b Process) int {
+	return cmp.Compare(a.Threads, b.Threads)
+}
+
+// CompareByVMSwap compares Process values by swap memory usage.
+func CompareByVMSwap(a Process, b Process) int {
+	return cmp.Compare(a.VMSwapKB(), b.VMSwapKB())
+}
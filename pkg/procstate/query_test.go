@@ -0,0 +1,225 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// TestPredicates asserts that processes collected from testdata files are
+// matched by predicates and their combinations.
+func TestPredicates(t *testing.T) {
+	t.Parallel()
+
+	processes := collectTestProcesses(t)
+
+	tests := map[string]struct {
+		pred Predicate
+		want []int
+	}{
+		"by name":                   {pred: ByName("java", "Web Content"), want: []int{4321, 7702}},
+		"by name with colon":        {pred: ByName("flush-253:0"), want: []int{1187}},
+		"by state":                  {pred: ByState(KernelAnyProcessStateSleeping), want: []int{1187, 389, 7702}},
+		"by state code":             {pred: ByStateCode('D', 'Z'), want: []int{31337, 4321}},
+		"by category":               {pred: ByCategory(StateCategoryIdle), want: []int{61012}},
+		"by severity":               {pred: BySeverity(SeverityCritical, SeverityWarning), want: []int{31337, 4321}},
+		"by pid":                    {pred: ByPid(389, 7702, 1), want: []int{389, 7702}},
+		"by parent":                 {pred: ByParent(KThreaddPID), want: []int{1187, 389, 61012}},
+		"by user":                   {pred: ByUser("1000"), want: []int{7702}},
+		"kernel thread":             {pred: IsKernelThread, want: []int{1187, 389, 61012}},
+		"not":                       {pred: Not(IsKernelThread), want: []int{31337, 4321, 7702}},
+		"and":                       {pred: And(IsKernelThread, ByState(KernelAnyProcessStateSleeping)), want: []int{1187, 389}},
+		"and without predicates":    {pred: And(), want: []int{1187, 31337, 389, 4321, 61012, 7702}},
+		"or":                        {pred: Or(ByStateCode('I'), ByName("java")), want: []int{4321, 61012}},
+		"or without predicates":     {pred: Or(), want: []int{}},
+		"nested":                    {pred: Or(And(IsKernelThread, ByStateCode('I')), Not(Or(IsKernelThread, ByStateCode('Z')))), want: []int{4321, 61012, 7702}},
+		"by state code unmatched":   {pred: ByStateCode('R'), want: []int{}},
+		"by name is case-sensitive": {pred: ByName("Java"), want: []int{}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pids(processes.Filter(tt.pred)); !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant pids %v\ngot pids  %v", tt.want, got)
+			}
+
+			if got := processes.CountFunc(tt.pred); got != len(tt.want) {
+				t.Errorf("want count %d, got %d", len(tt.want), got)
+			}
+
+			if got := processes.Any(tt.pred); got != (len(tt.want) > 0) {
+				t.Errorf("want any %t, got %t", len(tt.want) > 0, got)
+			}
+		})
+	}
+}
+
+// TestStateMatching asserts that ByState compares process states using
+// their normalized category while ByStateCode compares state codes as-is.
+// The legacy "T (tracing stop)" state shares a code with "T (stopped)" and a
+// category with "t (tracing stop)".
+func TestStateMatching(t *testing.T) {
+	t.Parallel()
+
+	processes := Processes{
+		{Pid: 1, State: KernelAnyProcessStateStopped},
+		{Pid: 2, State: KernelLegacyProcessStateTracingStop},
+		{Pid: 3, State: KernelCurrentProcessStateTracingStop},
+		{Pid: 4, State: ParseProcessState("T (unknown)")},
+	}
+
+	tests := map[string]struct {
+		pred Predicate
+		want []int
+	}{
+		"code T":                    {pred: ByStateCode('T'), want: []int{1, 2, 4}},
+		"code t":                    {pred: ByStateCode('t'), want: []int{3}},
+		"stopped state":             {pred: ByState(KernelAnyProcessStateStopped), want: []int{1}},
+		"legacy tracing stop":       {pred: ByState(KernelLegacyProcessStateTracingStop), want: []int{2, 3}},
+		"current tracing stop":      {pred: ByState(KernelCurrentProcessStateTracingStop), want: []int{2, 3}},
+		"tracing stop category":     {pred: ByCategory(StateCategoryTracingStop), want: []int{2, 3}},
+		"unknown state by value":    {pred: ByState(ParseProcessState("T (unknown)")), want: []int{4}},
+		"unknown state by category": {pred: ByCategory(StateCategoryUnknown), want: []int{4}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pids(processes.Filter(tt.pred)); !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant pids %v\ngot pids  %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestGroupBy asserts that processes are grouped by key with the order of
+// processes within each group retained.
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	processes := collectTestProcesses(t)
+
+	groups := processes.GroupBy(func(p Process) string {
+		return string(p.State.Category)
+	})
+
+	want := map[string][]int{
+		string(StateCategorySleeping):  {1187, 389, 7702},
+		string(StateCategoryZombie):    {31337},
+		string(StateCategoryDiskSleep): {4321},
+		string(StateCategoryIdle):      {61012},
+	}
+
+	got := make(map[string][]int, len(groups))
+	for key, group := range groups {
+		got[key] = pids(group)
+	}
+
+	if !maps.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("\nwant groups %v\ngot groups  %v", want, got)
+	}
+}
+
+// TestGroupByState asserts that state groups are ordered by severity and
+// then by the order of the known process state table.
+func TestGroupByState(t *testing.T) {
+	t.Parallel()
+
+	processes := collectTestProcesses(t)
+
+	var got []string
+	for _, group := range processes.GroupByState() {
+		got = append(got, group.State.String())
+	}
+
+	want := []string{"D (disk sleep)", "Z (zombie)", "S (sleeping)", "I (idle)"}
+	if !slices.Equal(got, want) {
+		t.Errorf("\nwant %q\ngot  %q", want, got)
+	}
+}
+
+// TestSortBy asserts that processes are sorted using each comparison
+// function in turn and that the sort is stable.
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	processes := Processes{
+		{Name: "b", Pid: 10, PPid: 1, Threads: 4, VMSwap: "2048 kB", State: KernelAnyProcessStateSleeping},
+		{Name: "a", Pid: 11, PPid: 2, Threads: 1, State: KernelAnyProcessStateZombie},
+		{Name: "c", Pid: 12, PPid: 1, Threads: 4, VMSwap: "1024 kB", State: KernelAnyProcessStateDiskSleep},
+		{Name: "a", Pid: 13, PPid: 1, Threads: 2, VMSwap: "0 kB", State: KernelAnyProcessStateRunning},
+		{Name: "d", Pid: 9, PPid: 2, Threads: 1, State: KernelCurrentProcessStateIdle},
+	}
+
+	tests := map[string]struct {
+		cmps []CompareFunc
+		want []int
+	}{
+		"no comparison functions":   {cmps: nil, want: []int{10, 11, 12, 13, 9}},
+		"pid":                       {cmps: []CompareFunc{CompareByPid}, want: []int{9, 10, 11, 12, 13}},
+		"ppid is stable":            {cmps: []CompareFunc{CompareByPPid}, want: []int{10, 12, 13, 11, 9}},
+		"name is stable":            {cmps: []CompareFunc{CompareByName}, want: []int{11, 13, 10, 12, 9}},
+		"name then reversed pid":    {cmps: []CompareFunc{CompareByName, Reverse(CompareByPid)}, want: []int{13, 11, 10, 12, 9}},
+		"state table order":         {cmps: []CompareFunc{CompareByState}, want: []int{13, 10, 12, 11, 9}},
+		"severity most severe":      {cmps: []CompareFunc{CompareBySeverity}, want: []int{12, 11, 10, 13, 9}},
+		"threads then name":         {cmps: []CompareFunc{CompareByThreads, CompareByName}, want: []int{11, 9, 13, 10, 12}},
+		"swap missing is zero":      {cmps: []CompareFunc{CompareByVMSwap}, want: []int{11, 13, 9, 12, 10}},
+		"reversed swap":             {cmps: []CompareFunc{Reverse(CompareByVMSwap)}, want: []int{10, 12, 11, 13, 9}},
+		"reversed threads then pid": {cmps: []CompareFunc{Reverse(CompareByThreads), CompareByPid}, want: []int{10, 12, 13, 9, 11}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			original := pids(processes)
+
+			if got := pids(processes.SortBy(tt.cmps...)); !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant pids %v\ngot pids  %v", tt.want, got)
+			}
+
+			if got := pids(processes); !slices.Equal(got, original) {
+				t.Errorf("original collection modified: want pids %v, got %v", original, got)
+			}
+		})
+	}
+}
+
+// TestTopN asserts that the first n processes are returned after sorting.
+func TestTopN(t *testing.T) {
+	t.Parallel()
+
+	processes := collectTestProcesses(t)
+
+	tests := map[string]struct {
+		n    int
+		want []int
+	}{
+		"top two by threads": {n: 2, want: []int{4321, 7702}},
+		"zero":               {n: 0, want: []int{}},
+		"all":                {n: 6, want: []int{4321, 7702, 1187, 31337, 389, 61012}},
+		"more than all":      {n: 10, want: []int{4321, 7702, 1187, 31337, 389, 61012}},
+		"negative":           {n: -1, want: []int{4321, 7702, 1187, 31337, 389, 61012}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := pids(processes.TopN(tt.n, Reverse(CompareByThreads)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant pids %v\ngot pids  %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -8,4 +8,7 @@
 // Package reports provides functions for generating one-line summary (Service
 // Output) and detailed overview (Long Service Output) "reports" of plugin
 // service check results.
+//
+// The exported API of this package follows the semantic versioning
+// guarantees of the procstate package.
 package reports
@@ -0,0 +1,279 @@
+// Copyright 2022 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package reports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-process/pkg/procstate"
+	"github.com/atc0005/go-nagios"
+)
+
+// CheckProcessOneLineSummary returns a one-line summary of the evaluation
+// results suitable for display and notification purposes.
+func CheckProcessOneLineSummary(summary procstate.Summary) string {
+	var oneLine string
+
+	switch {
+
+	case !summary.IsOKState():
+
+		summaryList := summary.StateList()
+		summaryList = append(summaryList, fmt.Sprintf(
+			"evaluated [%d]",
+			summary.Total,
+		))
+		if summary.Unexpected > 0 {
+			summaryList = append(summaryList, fmt.Sprintf(
+				"unexpected states [%d]",
+				summary.Unexpected,
+			))
+		}
+		procsSummary := strings.Join(summaryList, ", ")
+
+		oneLine = fmt.Sprintf(
+			"%s: %d problematic processes found (%s)",
+			summary.ServiceStateLabel,
+			summary.Problem,
+			procsSummary,
+		)
+
+	case summary.Unexpected > 0:
+		oneLine = fmt.Sprintf(
+			"%s: %d processes found in unexpected states (%d evaluated)",
+			summary.ServiceStateLabel,
+			summary.Unexpected,
+			summary.Total,
+		)
+
+	default:
+		oneLine = fmt.Sprintf(
+			"%s: No problematic processes found (%d evaluated)",
+			summary.ServiceStateLabel,
+			summary.Total,
+		)
+
+	}
+
+	return oneLine
+
+}
+
+// writeReportHeader generates a "header" or lead-in summary for the final
+// plugin report.
+func writeReportHeader(w io.Writer, summary procstate.Summary) {
+
+	_, _ = fmt.Fprintf(w, "Process Summary:%[1]s%[1]s", nagios.CheckOutputEOL)
+	for _, item := range summary.StateList() {
+		_, _ = fmt.Fprintf(w, "  - %s\n", item)
+	}
+	_, _ = fmt.Fprintf(w, "%[1]s%[1]s", nagios.CheckOutputEOL)
+
+}
+
+// writeReportProcessEntry generates a one-line summary of the given Process
+// for the final plugin report. The collection of gathered Process values is
+// provided in order to resolve dependencies between processes (e.g.,
+// ancestry).
+func writeReportProcessEntry(w io.Writer, p procstate.Process, processes procstate.Processes) {
+	parentProcess, err := p.ParentProcess(processes)
+	ppName, ppID := parentProcess.Name, parentProcess.Pid
+	if err != nil {
+		ppName = "missing"
+		ppID = -1
+	}
+
+	var kernelNote string
+	if p.KernelBlockedSeconds > 0 {
+		kernelNote = fmt.Sprintf(
+			", Note: kernel reported blocked for %d seconds",
+			p.KernelBlockedSeconds,
+		)
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		// "Name: %s\n\tParent: %v (%v)\n\tState: %v\n\tPid: %v\n\tPPid: %v\n\tThreads: %v\n\n",
+		"  - Name: %10s [Parent: %v (%v), State: %v, Pid: %v, PPid: %v, Threads: %v%s]%s",
+		p.Name,
+		ppName,
+		ppID,
+		p.State,
+		p.Pid,
+		p.PPid,
+		p.Threads,
+		kernelNote,
+		nagios.CheckOutputEOL,
+	)
+}
+
+// writeReportEntries generates a titled listing of the given process entries
+// for the final plugin report. The full collection of processes is provided
+// in order to resolve dependencies between processes (e.g., ancestry).
+func writeReportEntries(w io.Writer, title string, entries procstate.Processes, all procstate.Processes) {
+
+	_, _ = fmt.Fprintf(w, "%[1]s%[2]s:%[1]s", nagios.CheckOutputEOL, title)
+
+	switch {
+	case len(entries) > 0:
+		_, _ = fmt.Fprint(w, nagios.CheckOutputEOL)
+		for _, p := range entries {
+			writeReportProcessEntry(w, p, all)
+		}
+	default:
+		_, _ = fmt.Fprintf(w, "%[1]s  - None%[1]s", nagios.CheckOutputEOL)
+	}
+
+}
+
+// writeReportProblemEntries generates a listing of problem process entries
+// for the final plugin report. If specified, problem processes which are
+// kernel threads are listed separately from userland processes.
+func writeReportProblemEntries(w io.Writer, processes procstate.Processes, all procstate.Processes, separateKernelThreads bool) {
+
+	probProcs := processes.States(procstate.KnownProblemProcessStates())
+
+	switch {
+	case separateKernelThreads:
+		writeReportEntries(w, "Problems (userland)", probProcs.Userland(), all)
+		_, _ = fmt.Fprint(w, nagios.CheckOutputEOL)
+		writeReportEntries(w, "Problems (kernel threads)", probProcs.KernelThreads(), all)
+	default:
+		writeReportEntries(w, "Problems", probProcs, all)
+	}
+
+}
+
+// writeReportHungTaskContext generates a listing of the kernel hung task
+// detector settings for the final plugin report. These settings are provided
+// as context for processes reported as blocked by the kernel.
+func writeReportHungTaskContext(w io.Writer, summary procstate.Summary, settings procstate.HungTaskSettings) {
+
+	warnings := fmt.Sprintf("%d", settings.Warnings)
+	if settings.Warnings < 0 {
+		warnings = "unlimited"
+	}
+
+	_, _ = fmt.Fprintf(w, "Kernel Hung Task Detector:%[1]s%[1]s", nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - hung_task_timeout_secs [%d]%s", settings.TimeoutSecs, nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - hung_task_warnings [%s]%s", warnings, nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(
+		w,
+		"  - processes reported as blocked [%d]%s",
+		summary.KernelBlocked,
+		nagios.CheckOutputEOL,
+	)
+	_, _ = fmt.Fprintf(w, "%[1]s%[1]s", nagios.CheckOutputEOL)
+
+}
+
+// writeReportSnapshotContext generates a listing of the capture details for
+// the snapshot the evaluated processes were collected from.
+func writeReportSnapshotContext(w io.Writer, snapshot procstate.Snapshot) {
+
+	bootTime := "unknown"
+	if !snapshot.BootTime.IsZero() {
+		bootTime = snapshot.BootTime.Format(time.RFC3339)
+	}
+
+	_, _ = fmt.Fprintf(w, "%[1]sCollection:%[1]s%[1]s", nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - captured at [%s]%s", snapshot.CapturedAt.Format(time.RFC3339), nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - duration [%s]%s", snapshot.Duration().Round(time.Millisecond), nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - hostname [%s]%s", snapshot.Hostname, nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - kernel release [%s]%s", snapshot.KernelRelease, nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - boot time [%s]%s", bootTime, nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - proc root [%s]%s", snapshot.ProcRoot, nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - processes [%d]%s", len(snapshot.Processes), nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "  - errors [%d]%s", len(snapshot.Errors), nagios.CheckOutputEOL)
+	if snapshot.ToolVersion != "" {
+		_, _ = fmt.Fprintf(w, "  - tool version [%s]%s", snapshot.ToolVersion, nagios.CheckOutputEOL)
+	}
+
+}
+
+// ReportOptions is the collection of optional settings used to generate the
+// final plugin report.
+type ReportOptions struct {
+	// Ignored is the collection of problem processes ignored by
+	// user-specified rules. These processes are listed separately.
+	Ignored procstate.Processes
+
+	// HungTaskSettings are the kernel hung task detector settings. If
+	// provided they are included as context for any processes reported as
+	// blocked by the kernel.
+	HungTaskSettings *procstate.HungTaskSettings
+
+	// SeparateKernelThreads indicates whether problem processes which are
+	// kernel threads are listed separately from userland processes.
+	SeparateKernelThreads bool
+
+	// KernelRelease is the release of the running kernel used to determine
+	// the expected process states.
+	KernelRelease string
+
+	// UnexpectedStates is the collection of processes in states not
+	// expected for the running kernel. These processes are listed
+	// separately.
+	UnexpectedStates procstate.Processes
+
+	// Snapshot is the snapshot the evaluated processes were collected
+	// from. If provided, details of the capture are included so that the
+	// report may be traced back to the collection.
+	Snapshot *procstate.Snapshot
+}
+
+// CheckProcessReport returns a formatted report of the evaluation results
+// suitable for display and notification purposes. The summary is expected to
+// have been generated from the given collection of processes.
+func CheckProcessReport(processes procstate.Processes, summary procstate.Summary, opts ReportOptions) string {
+	var report strings.Builder
+
+	writeReportHeader(&report, summary)
+
+	if opts.HungTaskSettings != nil {
+		writeReportHungTaskContext(&report, summary, *opts.HungTaskSettings)
+	}
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"%[2]s%[1]s%[1]s",
+		nagios.CheckOutputEOL,
+		strings.Repeat("-", 50),
+	)
+
+	all := make(procstate.Processes, 0, len(processes)+len(opts.Ignored))
+	all = append(all, processes...)
+	all = append(all, opts.Ignored...)
+
+	writeReportProblemEntries(&report, processes, all, opts.SeparateKernelThreads)
+
+	if len(opts.UnexpectedStates) > 0 {
+		title := "Unexpected States"
+		if opts.KernelRelease != "" {
+			title = fmt.Sprintf("Unexpected States (kernel %s)", opts.KernelRelease)
+		}
+
+		_, _ = fmt.Fprint(&report, nagios.CheckOutputEOL)
+		writeReportEntries(&report, title, opts.UnexpectedStates, all)
+	}
+
+	if len(opts.Ignored) > 0 {
+		_, _ = fmt.Fprint(&report, nagios.CheckOutputEOL)
+		writeReportEntries(&report, "Ignored", opts.Ignored, all)
+	}
+
+	if opts.Snapshot != nil {
+		_, _ = fmt.Fprint(&report, nagios.CheckOutputEOL)
+		writeReportSnapshotContext(&report, *opts.Snapshot)
+	}
+
+	return report.String()
+}
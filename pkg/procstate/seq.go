@@ -0,0 +1,160 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"iter"
+	"path/filepath"
+)
+
+// SeqFromProcDirs returns an iterator which evaluates the status file within
+// each of the given /proc/[pid] directories and yields Process values as
+// they are read. All status file properties are retained. Processes which
+// exit during evaluation are skipped. If an error occurs it is yielded along
+// with a zero Process value; iteration continues with the next directory
+// unless the caller stops iterating.
+func SeqFromProcDirs(procDirs []string) iter.Seq2[Process, error] {
+	return seqFromProcDirs(ProcRootDir, procDirs, true, nil)
+}
+
+// All returns an iterator which evaluates each /proc/[pid] directory within
+// the proc filesystem and yields Process values matching all configured
+// filters as they are read. Unlike Collect, processes are evaluated one at a
+// time (the concurrency setting is not used) and are not retained; this
+// allows count-only checks to run in constant memory and searches to stop
+// at the first match.
+//
+// If the proc filesystem cannot be listed the error is yielded once and
+// iteration ends. If an error occurs evaluating a process it is yielded
+// along with a zero Process value; iteration continues with the next
+// process unless the caller stops iterating.
+func (c *Collector) All() iter.Seq2[Process, error] {
+	return func(yield func(Process, error) bool) {
+		procDirs, err := GetProcDirs(c.procRoot)
+		if err != nil {
+			yield(Process{}, err)
+			return
+		}
+
+		seq := seqFromProcDirs(c.procRoot, procDirs, c.allProperties, And(c.filters...))
+		for p, err := range seq {
+			if !yield(p, err) {
+				return
+			}
+		}
+	}
+}
+
+// seqFromProcDirs returns an iterator which evaluates each of the given
+// /proc/[pid] directories within the specified proc filesystem path and
+// yields Process values matching the (optional) filter.
+func seqFromProcDirs(procRoot string, procDirs []string, allProperties bool, filter Predicate) iter.Seq2[Process, error] {
+	return func(yield func(Process, error) bool) {
+		for _, procDir := range procDirs {
+			p, ok, err := processFromProcDir(filepath.Join(procRoot, procDir), allProperties)
+			switch {
+			case err != nil:
+				if !yield(Process{}, err) {
+					return
+				}
+
+			case !ok:
+				continue
+
+			case filter != nil && !filter(p):
+				continue
+
+			default:
+				if !yield(p, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All returns an iterator which yields each Process in the collection. A
+// nil error is yielded with each Process. This allows functions which
+// operate on iterators to be used with an existing collection.
+func (ps Processes) All() iter.Seq2[Process, error] {
+	return func(yield func(Process, error) bool) {
+		for _, p := range ps {
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSeq retrieves all Process values from the specified iterator and
+// returns them as a collection. Iteration stops at the first error, which is
+// returned.
+func CollectSeq(seq iter.Seq2[Process, error]) (Processes, error) {
+	processes := make(Processes, 0)
+	for p, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+
+		processes = append(processes, p)
+	}
+
+	return processes, nil
+}
+
+// AnySeq indicates whether the specified predicate function returns true for
+// any Process from the specified iterator. Iteration stops at the first
+// matching Process or at the first error, which is returned.
+func AnySeq(seq iter.Seq2[Process, error], pred func(Process) bool) (bool, error) {
+	for p, err := range seq {
+		if err != nil {
+			return false, err
+		}
+
+		if pred(p) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CountSeq returns the number of Process values from the specified iterator
+// for which the specified predicate function returns true. Iteration stops
+// at the first error, which is returned.
+func CountSeq(seq iter.Seq2[Process, error], pred func(Process) bool) (int, error) {
+	var ctr int
+	for p, err := range seq {
+		if err != nil {
+			return 0, err
+		}
+
+		if pred(p) {
+			ctr++
+		}
+	}
+
+	return ctr, nil
+}
+
+// SummarizeSeq generates a structured summary of the evaluation results for
+// all Process values from the specified iterator using the specified
+// options. Process values are not retained. Iteration stops at the first
+// error, which is returned.
+func SummarizeSeq(seq iter.Seq2[Process, error], opts SummaryOptions) (Summary, error) {
+	var builder SummaryBuilder
+	for p, err := range seq {
+		if err != nil {
+			return Summary{}, err
+		}
+
+		builder.Add(p)
+	}
+
+	return builder.Summary(opts), nil
+}
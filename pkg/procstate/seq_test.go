@@ -0,0 +1,310 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// newTestCollector creates a Collector for a proc filesystem created from
+// testdata files using the specified options. If corrupt is set, the status
+// file of process 4321 is modified so that the process cannot be
+// evaluated.
+func newTestCollector(t *testing.T, corrupt bool, opts ...Option) *Collector {
+	t.Helper()
+
+	procRoot := newTestProcRoot(t)
+
+	if corrupt {
+		statusFile := filepath.Join(procRoot, "4321", ProcStatusFilename)
+		data, err := os.ReadFile(statusFile)
+		if err != nil {
+			t.Fatalf("failed to read status file: %v", err)
+		}
+
+		data = bytes.Replace(data, []byte("Threads:\t87"), []byte("Threads:\teighty-seven"), 1)
+		if err := os.WriteFile(statusFile, data, 0o600); err != nil {
+			t.Fatalf("failed to write status file: %v", err)
+		}
+	}
+
+	collector, err := NewCollector(append([]Option{WithProcRoot(procRoot)}, opts...)...)
+	if err != nil {
+		t.Fatalf("failed to create collector: %v", err)
+	}
+
+	return collector
+}
+
+// countYields wraps the given iterator, counting the number of values
+// yielded to the caller.
+func countYields(seq iter.Seq2[Process, error], yields *int) iter.Seq2[Process, error] {
+	return func(yield func(Process, error) bool) {
+		for p, err := range seq {
+			*yields++
+			if !yield(p, err) {
+				return
+			}
+		}
+	}
+}
+
+// TestCollectorAll asserts that the iterator yields the same processes as
+// Collect, applies the configured filters, yields errors for processes
+// which could not be evaluated without stopping and stops when the caller
+// stops iterating.
+func TestCollectorAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("matches collect", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, false)
+
+		want, err := collector.Collect()
+		if err != nil {
+			t.Fatalf("failed to collect processes: %v", err)
+		}
+
+		got, err := CollectSeq(collector.All())
+		if err != nil {
+			t.Fatalf("failed to collect processes from iterator: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("\nwant %+v\ngot  %+v", want, got)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, false, WithFilter(Not(IsKernelThread)))
+
+		got, err := CollectSeq(collector.All())
+		if err != nil {
+			t.Fatalf("failed to collect processes from iterator: %v", err)
+		}
+
+		if want := []int{31337, 4321, 7702}; !slices.Equal(pids(got), want) {
+			t.Errorf("\nwant pids %v\ngot pids  %v", want, pids(got))
+		}
+	})
+
+	t.Run("process errors", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, true)
+
+		var got []int
+		var errs int
+		for p, err := range collector.All() {
+			if err != nil {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Errorf("want parse error, got %v", err)
+				}
+				errs++
+
+				continue
+			}
+
+			got = append(got, p.Pid)
+		}
+
+		if errs != 1 {
+			t.Errorf("want 1 error, got %d", errs)
+		}
+
+		if want := []int{1187, 31337, 389, 61012, 7702}; !slices.Equal(got, want) {
+			t.Errorf("\nwant pids %v\ngot pids  %v", want, got)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, false)
+
+		// The iterator panics if values are yielded after the loop ends.
+		var got []int
+		for p, err := range collector.All() {
+			if err != nil {
+				t.Fatalf("failed to evaluate process: %v", err)
+			}
+
+			got = append(got, p.Pid)
+			if len(got) == 2 {
+				break
+			}
+		}
+
+		if want := []int{1187, 31337}; !slices.Equal(got, want) {
+			t.Errorf("\nwant pids %v\ngot pids  %v", want, got)
+		}
+	})
+
+	t.Run("missing proc root", func(t *testing.T) {
+		t.Parallel()
+
+		collector, err := NewCollector(WithProcRoot(filepath.Join(t.TempDir(), "missing")))
+		if err != nil {
+			t.Fatalf("failed to create collector: %v", err)
+		}
+
+		var errs []error
+		for _, err := range collector.All() {
+			errs = append(errs, err)
+		}
+
+		if len(errs) != 1 || !errors.Is(errs[0], fs.ErrNotExist) {
+			t.Errorf("want a single error wrapping %v, got %v", fs.ErrNotExist, errs)
+		}
+	})
+}
+
+// TestAnySeq asserts that iteration stops at the first matching process or
+// at the first error.
+func TestAnySeq(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		corrupt    bool
+		pred       Predicate
+		want       bool
+		wantYields int
+		wantErr    bool
+	}{
+		"first process matches": {
+			pred:       ByPid(1187),
+			want:       true,
+			wantYields: 1,
+		},
+		"later process matches": {
+			pred:       ByPid(389),
+			want:       true,
+			wantYields: 3,
+		},
+		"no match": {
+			pred:       ByPid(1),
+			wantYields: 6,
+		},
+		"error before match": {
+			corrupt:    true,
+			pred:       ByPid(7702),
+			wantYields: 4,
+			wantErr:    true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := newTestCollector(t, tt.corrupt)
+
+			var yields int
+			got, err := AnySeq(countYields(collector.All(), &yields), tt.pred)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("want %t, got %t", tt.want, got)
+			}
+
+			if yields != tt.wantYields {
+				t.Errorf("want %d processes evaluated, got %d", tt.wantYields, yields)
+			}
+		})
+	}
+}
+
+// TestCountSeq asserts that matching processes are counted and that
+// iteration stops at the first error.
+func TestCountSeq(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		corrupt bool
+		pred    Predicate
+		want    int
+		wantErr bool
+	}{
+		"kernel threads": {
+			pred: IsKernelThread,
+			want: 3,
+		},
+		"problem states": {
+			pred: ByState(KnownProblemProcessStates()...),
+			want: 2,
+		},
+		"no match": {
+			pred: ByPid(1),
+		},
+		"error": {
+			corrupt: true,
+			pred:    IsKernelThread,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := newTestCollector(t, tt.corrupt)
+
+			got, err := CountSeq(collector.All(), tt.pred)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %t, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("want %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSummarizeSeq asserts that the summary generated from an iterator
+// matches the summary generated for the collected processes and that an
+// error stops iteration.
+func TestSummarizeSeq(t *testing.T) {
+	t.Parallel()
+
+	collector := newTestCollector(t, false)
+
+	processes, err := collector.Collect()
+	if err != nil {
+		t.Fatalf("failed to collect processes: %v", err)
+	}
+
+	opts := SummaryOptions{Ignored: processes.Filter(ByPid(31337))}
+
+	got, err := SummarizeSeq(collector.All(), opts)
+	if err != nil {
+		t.Fatalf("failed to summarize processes: %v", err)
+	}
+
+	if want := processes.Summary(opts); !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, got)
+	}
+
+	if _, err := SummarizeSeq(newTestCollector(t, true).All(), opts); err == nil {
+		t.Errorf("want error for process which could not be evaluated, got nil")
+	}
+}
@@ -5,7 +5,7 @@
 // Licensed under the MIT License. See LICENSE file in the project root for
 // full license information.
 
-package process
+package procstate
 
 import (
 	"fmt"
@@ -21,10 +21,14 @@ func (p *Process) setProcessProps() error {
 	const requiredPropsIndexCount = 6
 
 	if len(p.AllProperties) < requiredPropsIndexCount {
-		return fmt.Errorf(
-			"error setting process field values: %w",
-			ErrInvalidProcessPropertiesIndexCount,
-		)
+		return &ParseError{
+			Err: fmt.Errorf(
+				"got %d properties, expected at least %d: %w",
+				len(p.AllProperties),
+				requiredPropsIndexCount,
+				ErrInvalidProcessPropertiesIndexCount,
+			),
+		}
 	}
 
 	// 	type setter func() error
@@ -63,7 +67,11 @@ func (p *Process) setProcessProps() error {
 		return err
 	}
 
-	return p.setVMSwapField()
+	if err := p.setVMSwapField(); err != nil {
+		return err
+	}
+
+	return p.setVMRSSField()
 }
 
 // setNameField retrieves the process property name value and sets the
@@ -71,11 +79,10 @@ func (p *Process) setProcessProps() error {
 func (p *Process) setNameField() error {
 	name, ok := p.AllProperties[ProcessNameField]
 	if !ok {
-		return fmt.Errorf(
-			"process property %s: %w",
-			ProcessNameField,
-			ErrMissingProcessPropertiesIndexEntry,
-		)
+		return &ParseError{
+			Key: ProcessNameField,
+			Err: ErrMissingProcessPropertiesIndexEntry,
+		}
 	}
 	p.Name = name
 
@@ -87,13 +94,12 @@ func (p *Process) setNameField() error {
 func (p *Process) setStateField() error {
 	state, ok := p.AllProperties[ProcessStateField]
 	if !ok {
-		return fmt.Errorf(
-			"process property %s: %w",
-			ProcessStateField,
-			ErrMissingProcessPropertiesIndexEntry,
-		)
+		return &ParseError{
+			Key: ProcessStateField,
+			Err: ErrMissingProcessPropertiesIndexEntry,
+		}
 	}
-	p.State = state
+	p.State = ParseProcessState(state)
 
 	return nil
 }
@@ -103,20 +109,18 @@ func (p *Process) setStateField() error {
 func (p *Process) setPidField() error {
 	pidStr, ok := p.AllProperties[ProcessPidField]
 	if !ok {
-		return fmt.Errorf(
-			"process property %s: %w",
-			ProcessPidField,
-			ErrMissingProcessPropertiesIndexEntry,
-		)
+		return &ParseError{
+			Key: ProcessPidField,
+			Err: ErrMissingProcessPropertiesIndexEntry,
+		}
 	}
 	n, err := strconv.Atoi(pidStr)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to convert value %s for property %s as number: %w",
-			pidStr,
-			ProcessPidField,
-			ErrInvalidProcStatusLineValue,
-		)
+		return &ParseError{
+			Key:   ProcessPidField,
+			Value: pidStr,
+			Err:   ErrInvalidProcStatusLineValue,
+		}
 	}
 	p.Pid = n
 
@@ -128,20 +132,18 @@ func (p *Process) setPidField() error {
 func (p *Process) setPPidField() error {
 	ppidStr, ok := p.AllProperties[ProcessPPidField]
 	if !ok {
-		return fmt.Errorf(
-			"process property %s: %w",
-			ProcessPPidField,
-			ErrMissingProcessPropertiesIndexEntry,
-		)
+		return &ParseError{
+			Key: ProcessPPidField,
+			Err: ErrMissingProcessPropertiesIndexEntry,
+		}
 	}
 	n, err := strconv.Atoi(ppidStr)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to convert value %s for property %s as number: %w",
-			ppidStr,
-			ProcessPPidField,
-			ErrInvalidProcStatusLineValue,
-		)
+		return &ParseError{
+			Key:   ProcessPPidField,
+			Value: ppidStr,
+			Err:   ErrInvalidProcStatusLineValue,
+		}
 	}
 	p.PPid = n
 
@@ -153,20 +155,18 @@ func (p *Process) setPPidField() error {
 func (p *Process) setThreadsField() error {
 	threadsStr, ok := p.AllProperties[ProcessThreadsField]
 	if !ok {
-		return fmt.Errorf(
-			"process property %s: %w",
-			ProcessThreadsField,
-			ErrMissingProcessPropertiesIndexEntry,
-		)
+		return &ParseError{
+			Key: ProcessThreadsField,
+			Err: ErrMissingProcessPropertiesIndexEntry,
+		}
 	}
 	n, err := strconv.Atoi(threadsStr)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to convert value %s for property %s as number: %w",
-			threadsStr,
-			ProcessThreadsField,
-			ErrInvalidProcStatusLineValue,
-		)
+		return &ParseError{
+			Key:   ProcessThreadsField,
+			Value: threadsStr,
+			Err:   ErrInvalidProcStatusLineValue,
+		}
 	}
 	p.Threads = n
 
@@ -194,3 +194,16 @@ func (p *Process) setVMSwapField() error {
 
 	return nil
 }
+
+// setVMRSSField retrieves the process property vmrss value and sets the
+// "primary" VMRSS field for direct access.
+//
+// NOTE: This value is not set for kernel threads. As with the VMSwap field,
+// we use it if available, otherwise we do *not* return an error.
+func (p *Process) setVMRSSField() error {
+	if vmrss, ok := p.AllProperties[ProcessVMRSSField]; ok {
+		p.VMRSS = vmrss
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// signalNames is the index of standard signal names by signal number. The
+// numbering is the one used by most architectures (including x86 and ARM);
+// the Alpha, MIPS and SPARC architectures differ.
+//
+// https://man7.org/linux/man-pages/man7/signal.7.html
+var signalNames = [...]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	4:  "SIGILL",
+	5:  "SIGTRAP",
+	6:  "SIGABRT",
+	7:  "SIGBUS",
+	8:  "SIGFPE",
+	9:  "SIGKILL",
+	10: "SIGUSR1",
+	11: "SIGSEGV",
+	12: "SIGUSR2",
+	13: "SIGPIPE",
+	14: "SIGALRM",
+	15: "SIGTERM",
+	16: "SIGSTKFLT",
+	17: "SIGCHLD",
+	18: "SIGCONT",
+	19: "SIGSTOP",
+	20: "SIGTSTP",
+	21: "SIGTTIN",
+	22: "SIGTTOU",
+	23: "SIGURG",
+	24: "SIGXCPU",
+	25: "SIGXFSZ",
+	26: "SIGVTALRM",
+	27: "SIGPROF",
+	28: "SIGWINCH",
+	29: "SIGIO",
+	30: "SIGPWR",
+	31: "SIGSYS",
+}
+
+// Real-time signal numbers. Signals 32 and 33 are reserved by the glibc
+// threading implementation, so user-visible real-time signals start at 34.
+const (
+	signalRTMinKernel int = 32
+	signalRTMin       int = 34
+	signalMax         int = 64
+)
+
+// SignalMaskFields returns the names of the process status fields which
+// provide signal masks.
+func SignalMaskFields() []string {
+	return []string{
+		ProcessSigPndField,
+		ProcessShdPndField,
+		ProcessSigBlkField,
+		ProcessSigIgnField,
+		ProcessSigCgtField,
+	}
+}
+
+// SignalName returns the name of the specified signal number (e.g.,
+// "SIGTERM" or "SIGRTMIN+2"), matching the names listed by kill -l.
+func SignalName(sig int) string {
+	switch {
+	case sig > 0 && sig < len(signalNames):
+		return signalNames[sig]
+	case sig >= signalRTMin && sig <= signalMax:
+		return fmt.Sprintf("SIGRTMIN+%d", sig-signalRTMin)
+	case sig >= signalRTMinKernel && sig <= signalMax:
+		return fmt.Sprintf("SIG%d", sig)
+	default:
+		return fmt.Sprintf("signal %d", sig)
+	}
+}
+
+// DecodeSignalMask returns the names of the signals set in the given
+// hexadecimal signal mask (e.g., "0000000000004a02") as found in the
+// SigBlk, SigIgn and related fields of a /proc/[pid]/status file. The
+// returned list is empty if no signals are set.
+func DecodeSignalMask(mask string) ([]string, error) {
+	bits, err := strconv.ParseUint(strings.TrimSpace(mask), 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", mask, ErrInvalidSignalMask)
+	}
+
+	var names []string
+	for sig := 1; sig <= signalMax; sig++ {
+		if bits&(1<<(sig-1)) != 0 {
+			names = append(names, SignalName(sig))
+		}
+	}
+
+	return names, nil
+}
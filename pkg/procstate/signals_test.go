@@ -0,0 +1,124 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// TestDecodeSignalMask asserts that the signals set in hexadecimal signal
+// masks are named, including real-time signals, and that malformed masks
+// are rejected.
+func TestDecodeSignalMask(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mask    string
+		want    []string
+		wantErr bool
+	}{
+		"no signals": {
+			mask: "0000000000000000",
+		},
+		"standard signals": {
+			mask: "0000000000004a02",
+			want: []string{"SIGINT", "SIGUSR1", "SIGUSR2", "SIGTERM"},
+		},
+		"first and last standard signals": {
+			mask: "0000000040000001",
+			want: []string{"SIGHUP", "SIGSYS"},
+		},
+		"glibc reserved real-time signals": {
+			mask: "0000000180000000",
+			want: []string{"SIG32", "SIG33"},
+		},
+		"real-time signals": {
+			mask: "8000000600000000",
+			want: []string{"SIGRTMIN+0", "SIGRTMIN+1", "SIGRTMIN+30"},
+		},
+		"all signals": {
+			mask: "ffffffffffffffff",
+			want: func() []string {
+				names := make([]string, 0, signalMax)
+				for sig := 1; sig <= signalMax; sig++ {
+					names = append(names, SignalName(sig))
+				}
+
+				return names
+			}(),
+		},
+		"surrounding whitespace": {
+			mask: " 0000000000000100\n",
+			want: []string{"SIGKILL"},
+		},
+		"short mask": {
+			mask: "4",
+			want: []string{"SIGQUIT"},
+		},
+		"empty": {
+			mask:    "",
+			wantErr: true,
+		},
+		"non-hexadecimal": {
+			mask:    "000000000000zz02",
+			wantErr: true,
+		},
+		"prefixed": {
+			mask:    "0x4a02",
+			wantErr: true,
+		},
+		"too long": {
+			mask:    "10000000000000000",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DecodeSignalMask(tt.mask)
+			switch {
+			case tt.wantErr && !errors.Is(err, ErrInvalidSignalMask):
+				t.Fatalf("want error wrapping %v, got %v", ErrInvalidSignalMask, err)
+			case !tt.wantErr && err != nil:
+				t.Fatalf("failed to decode %q: %v", tt.mask, err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("\nwant %q\ngot  %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSignalName asserts that signal numbers are named as listed by kill
+// -l, including real-time signals.
+func TestSignalName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[int]string{
+		1:  "SIGHUP",
+		9:  "SIGKILL",
+		31: "SIGSYS",
+		32: "SIG32",
+		33: "SIG33",
+		34: "SIGRTMIN+0",
+		64: "SIGRTMIN+30",
+		0:  "signal 0",
+		65: "signal 65",
+	}
+
+	for sig, want := range tests {
+		if got := SignalName(sig); got != want {
+			t.Errorf("signal %d: want %q, got %q", sig, want, got)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SnapshotFormatVersion is the version of the Snapshot JSON format
+// supported by this package. Snapshots using a newer format version are
+// rejected when read.
+const SnapshotFormatVersion int = 2
+
+// Snapshot is a collection of processes along with details of when and where
+// the collection was captured. Reports and machine readable output generated
+// from a Snapshot can be traced back to the exact collection they came from.
+type Snapshot struct {
+	// FormatVersion is the version of the Snapshot JSON format.
+	FormatVersion int `json:"format_version"`
+
+	// CapturedAt is the time the capture started.
+	CapturedAt time.Time `json:"captured_at"`
+
+	// DurationSeconds is the time taken to capture the snapshot in
+	// seconds.
+	DurationSeconds float64 `json:"duration_seconds"`
+
+	// BootTime is the time the system was booted. This value is the zero
+	// time value if the boot time could not be determined.
+	BootTime time.Time `json:"boot_time"`
+
+	// Hostname is the hostname of the system.
+	Hostname string `json:"hostname"`
+
+	// KernelRelease is the release of the running kernel (e.g.,
+	// "4.18.0-553.el8_10.x86_64").
+	KernelRelease string `json:"kernel_release"`
+
+	// ProcRoot is the base path of the proc filesystem the snapshot was
+	// captured from.
+	ProcRoot string `json:"proc_root"`
+
+	// ToolVersion is the version of the application which captured the
+	// snapshot. This value is set by the caller.
+	ToolVersion string `json:"tool_version,omitempty"`
+
+	// Errors is the collection of errors encountered during the capture.
+	// Processes which could not be evaluated are not included in the
+	// snapshot.
+	Errors []SnapshotError `json:"errors,omitempty"`
+
+	// Processes is the collection of captured processes. This value is
+	// nil if processes were not recorded (e.g., JSON output generated
+	// without the show-all flag).
+	Processes Processes `json:"processes"`
+}
+
+// SnapshotError records an error encountered while capturing a Snapshot.
+type SnapshotError struct {
+	// Pid is the process ID of the process which could not be evaluated.
+	// This value is zero for errors not specific to a process (e.g., a
+	// failure to determine the system boot time).
+	Pid int `json:"pid,omitempty"`
+
+	// Message is the error message.
+	Message string `json:"error"`
+
+	// Err is the original error. This value is not retained when a
+	// snapshot is written as JSON.
+	Err error `json:"-"`
+}
+
+// Error provides the error message.
+func (e SnapshotError) Error() string {
+	if e.Pid != 0 {
+		return fmt.Sprintf("pid %d: %s", e.Pid, e.Message)
+	}
+
+	return e.Message
+}
+
+// Unwrap returns the original error if available.
+func (e SnapshotError) Unwrap() error {
+	return e.Err
+}
+
+// newSnapshotError creates a SnapshotError for the specified process ID (or
+// zero if not specific to a process) and error.
+func newSnapshotError(pid int, err error) SnapshotError {
+	return SnapshotError{
+		Pid:     pid,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
+
+// Snapshot evaluates each /proc/[pid] directory within the proc filesystem
+// and returns a Snapshot of the Process values matching all configured
+// filters along with details of the capture. Unlike Collect, errors
+// encountered evaluating specific processes or gathering capture details
+// are recorded in the snapshot instead of being returned. An error is
+// returned if the proc filesystem cannot be listed.
+func (c *Collector) Snapshot() (Snapshot, error) {
+	start := time.Now()
+
+	processes, procErrs, err := c.collect()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		FormatVersion: SnapshotFormatVersion,
+		CapturedAt:    start,
+		ProcRoot:      c.procRoot,
+		Processes:     processes,
+	}
+
+	for _, procErr := range procErrs {
+		pid, _ := strconv.Atoi(procErr.procDir)
+		snapshot.Errors = append(snapshot.Errors, newSnapshotError(pid, procErr.err))
+	}
+
+	if bootTime, err := GetBootTime(c.procRoot); err != nil {
+		snapshot.Errors = append(snapshot.Errors, newSnapshotError(0, err))
+	} else {
+		snapshot.BootTime = bootTime
+	}
+
+	if hostname, err := GetHostname(c.procRoot); err != nil {
+		snapshot.Errors = append(snapshot.Errors, newSnapshotError(0, err))
+	} else {
+		snapshot.Hostname = hostname
+	}
+
+	if kr, err := GetKernelRelease(c.procRoot); err != nil {
+		snapshot.Errors = append(snapshot.Errors, newSnapshotError(0, err))
+	} else {
+		snapshot.KernelRelease = kr.String()
+	}
+
+	snapshot.DurationSeconds = time.Since(start).Seconds()
+
+	return snapshot, nil
+}
+
+// Duration returns the time taken to capture the snapshot.
+func (s Snapshot) Duration() time.Duration {
+	return time.Duration(s.DurationSeconds * float64(time.Second))
+}
+
+// ProcessErrors returns the errors recorded for processes which could not
+// be evaluated.
+func (s Snapshot) ProcessErrors() []SnapshotError {
+	errs := make([]SnapshotError, 0, len(s.Errors))
+	for _, e := range s.Errors {
+		if e.Pid != 0 {
+			errs = append(errs, e)
+		}
+	}
+
+	return errs
+}
+
+// Summary generates a structured summary of the evaluation results for the
+// snapshot processes using the specified options.
+func (s Snapshot) Summary(opts SummaryOptions) Summary {
+	return s.Processes.Summary(opts)
+}
+
+// ReadSnapshot reads a snapshot in JSON format (e.g., as written by the
+// lsps json output format) from the specified io.Reader. Fields not part of
+// the Snapshot JSON format are ignored.
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var snapshot Snapshot
+
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(&snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to decode snapshot JSON: %w", err)
+	}
+
+	if snapshot.FormatVersion < 1 || snapshot.FormatVersion > SnapshotFormatVersion {
+		return Snapshot{}, fmt.Errorf(
+			"snapshot format version %d (supported: 1-%d): %w",
+			snapshot.FormatVersion,
+			SnapshotFormatVersion,
+			ErrUnsupportedSnapshotFormat,
+		)
+	}
+
+	return snapshot, nil
+}
+
+// ReadSnapshotFile reads a snapshot in JSON format from the specified file.
+func ReadSnapshotFile(filename string) (Snapshot, error) {
+	f, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to open snapshot file %s: %w", filename, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	snapshot, err := ReadSnapshot(bufio.NewReader(f))
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to read snapshot file %s: %w", filename, err)
+	}
+
+	return snapshot, nil
+}
+
+// GetBootTime retrieves the system boot time from the btime entry of the
+// stat file within the given base path (usually "/proc").
+func GetBootTime(path string) (time.Time, error) {
+	qualifiedPath := filepath.Join(path, ProcSystemStatFilename)
+	data, err := os.ReadFile(filepath.Clean(qualifiedPath))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read file %s: %w", qualifiedPath, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var lineNum int
+	for scanner.Scan() {
+		lineNum++
+
+		value, found := strings.CutPrefix(scanner.Text(), "btime ")
+		if !found {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return time.Time{}, &ParseError{
+				Path:  qualifiedPath,
+				Line:  lineNum,
+				Key:   "btime",
+				Value: value,
+				Err:   ErrInvalidBootTime,
+			}
+		}
+
+		return time.Unix(seconds, 0), nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse file %s: %w", qualifiedPath, err)
+	}
+
+	return time.Time{}, &ParseError{
+		Path: qualifiedPath,
+		Key:  "btime",
+		Err:  ErrInvalidBootTime,
+	}
+}
+
+// GetHostname retrieves the system hostname from the given base path
+// (usually "/proc").
+func GetHostname(path string) (string, error) {
+	qualifiedPath := filepath.Join(path, ProcKernelHostnameFilename)
+	data, err := os.ReadFile(filepath.Clean(qualifiedPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", qualifiedPath, err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
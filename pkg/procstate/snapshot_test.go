@@ -0,0 +1,265 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeTestSystemFiles writes the system files evaluated when capturing a
+// Snapshot to the specified proc filesystem.
+func writeTestSystemFiles(t *testing.T, procRoot string) {
+	t.Helper()
+
+	files := map[string]string{
+		ProcSystemStatFilename:     "cpu  10132153 290696 3084719 46828483 16683 0 25195 0 0 0\nintr 1462898\nctxt 1990473\nbtime 1709544600\nprocesses 2915\n",
+		ProcKernelHostnameFilename: "node1.example.com\n",
+		ProcKernelReleaseFilename:  "4.18.0-513.5.1.el8_9.x86_64\n",
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(procRoot, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to create proc file: %v", err)
+		}
+	}
+}
+
+// TestCollectorSnapshot asserts that a Snapshot records the collected
+// processes along with the capture details and that errors are recorded
+// instead of being returned.
+func TestCollectorSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("capture details", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, false)
+		writeTestSystemFiles(t, collector.procRoot)
+
+		want, err := collector.Collect()
+		if err != nil {
+			t.Fatalf("failed to collect processes: %v", err)
+		}
+
+		before := time.Now()
+
+		snapshot, err := collector.Snapshot()
+		if err != nil {
+			t.Fatalf("failed to capture snapshot: %v", err)
+		}
+
+		if len(snapshot.Errors) != 0 {
+			t.Errorf("want no errors, got %v", snapshot.Errors)
+		}
+
+		if !reflect.DeepEqual(snapshot.Processes, want) {
+			t.Errorf("\nwant %+v\ngot  %+v", want, snapshot.Processes)
+		}
+
+		if snapshot.FormatVersion != SnapshotFormatVersion ||
+			snapshot.ProcRoot != collector.procRoot ||
+			snapshot.Hostname != "node1.example.com" ||
+			snapshot.KernelRelease != "4.18.0-513.5.1.el8_9.x86_64" ||
+			!snapshot.BootTime.Equal(time.Unix(1709544600, 0)) {
+			t.Errorf("unexpected capture details: %+v", snapshot)
+		}
+
+		if snapshot.CapturedAt.Before(before) || snapshot.Duration() < 0 {
+			t.Errorf(
+				"unexpected capture time %v (duration %v) for capture started after %v",
+				snapshot.CapturedAt,
+				snapshot.Duration(),
+				before,
+			)
+		}
+	})
+
+	t.Run("missing capture details", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, false)
+
+		snapshot, err := collector.Snapshot()
+		if err != nil {
+			t.Fatalf("failed to capture snapshot: %v", err)
+		}
+
+		// The boot time, hostname and kernel release could not be read.
+		if len(snapshot.Errors) != 3 {
+			t.Fatalf("want 3 errors, got %v", snapshot.Errors)
+		}
+
+		for _, snapshotErr := range snapshot.Errors {
+			if snapshotErr.Pid != 0 || !errors.Is(snapshotErr, fs.ErrNotExist) {
+				t.Errorf("want error wrapping %v not specific to a process, got %+v", fs.ErrNotExist, snapshotErr)
+			}
+		}
+
+		if errs := snapshot.ProcessErrors(); len(errs) != 0 {
+			t.Errorf("want no process errors, got %v", errs)
+		}
+
+		if !snapshot.BootTime.IsZero() || snapshot.Hostname != "" || snapshot.KernelRelease != "" {
+			t.Errorf("want unset capture details, got %+v", snapshot)
+		}
+
+		if len(snapshot.Processes) != len(testProcFixtures) {
+			t.Errorf("want %d processes, got %d", len(testProcFixtures), len(snapshot.Processes))
+		}
+	})
+
+	t.Run("process errors", func(t *testing.T) {
+		t.Parallel()
+
+		collector := newTestCollector(t, true)
+		writeTestSystemFiles(t, collector.procRoot)
+
+		snapshot, err := collector.Snapshot()
+		if err != nil {
+			t.Fatalf("failed to capture snapshot: %v", err)
+		}
+
+		errs := snapshot.ProcessErrors()
+		if len(errs) != 1 || len(snapshot.Errors) != 1 {
+			t.Fatalf("want a single process error, got %v", snapshot.Errors)
+		}
+
+		if errs[0].Pid != 4321 {
+			t.Errorf("want error for process 4321, got %+v", errs[0])
+		}
+
+		var parseErr *ParseError
+		if !errors.As(errs[0], &parseErr) || parseErr.Key != "threads" {
+			t.Errorf("want parse error for threads property, got %v", errs[0])
+		}
+
+		if got := snapshot.Processes.Filter(ByPid(4321)); len(got) != 0 {
+			t.Errorf("want process 4321 excluded from snapshot, got %+v", got)
+		}
+
+		if len(snapshot.Processes) != len(testProcFixtures)-1 {
+			t.Errorf("want %d processes, got %d", len(testProcFixtures)-1, len(snapshot.Processes))
+		}
+	})
+
+	t.Run("missing proc root", func(t *testing.T) {
+		t.Parallel()
+
+		collector, err := NewCollector(WithProcRoot(filepath.Join(t.TempDir(), "missing")))
+		if err != nil {
+			t.Fatalf("failed to create collector: %v", err)
+		}
+
+		if _, err := collector.Snapshot(); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("want error wrapping %v, got %v", fs.ErrNotExist, err)
+		}
+	})
+}
+
+// TestGetBootTime asserts that the boot time is read from the btime entry
+// of the system stat file and that missing or malformed entries are
+// reported.
+func TestGetBootTime(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content  string
+		missing  bool
+		want     time.Time
+		wantErr  error
+		wantLine int
+	}{
+		"btime entry": {
+			content: "cpu  1 2 3 4\nbtime 1709544600\nprocesses 2915\n",
+			want:    time.Unix(1709544600, 0),
+		},
+		"btime entry without trailing newline": {
+			content: "cpu  1 2 3 4\nbtime 1709544600",
+			want:    time.Unix(1709544600, 0),
+		},
+		"malformed btime entry": {
+			content:  "cpu  1 2 3 4\nbtime yesterday\n",
+			wantErr:  ErrInvalidBootTime,
+			wantLine: 2,
+		},
+		"missing btime entry": {
+			content: "cpu  1 2 3 4\nprocesses 2915\n",
+			wantErr: ErrInvalidBootTime,
+		},
+		"btime prefix of another entry": {
+			content: "btimex 1709544600\n",
+			wantErr: ErrInvalidBootTime,
+		},
+		"missing file": {
+			missing: true,
+			wantErr: fs.ErrNotExist,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			procRoot := t.TempDir()
+			if !tt.missing {
+				filename := filepath.Join(procRoot, ProcSystemStatFilename)
+				if err := os.WriteFile(filename, []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("failed to create stat file: %v", err)
+				}
+			}
+
+			got, err := GetBootTime(procRoot)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want error wrapping %v, got %v", tt.wantErr, err)
+			}
+
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) && parseErr.Line != tt.wantLine {
+				t.Errorf("want error for line %d, got line %d", tt.wantLine, parseErr.Line)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestGetHostname asserts that the hostname is read without surrounding
+// whitespace and that a missing file is reported.
+func TestGetHostname(t *testing.T) {
+	t.Parallel()
+
+	procRoot := t.TempDir()
+
+	if _, err := GetHostname(procRoot); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("want error wrapping %v, got %v", fs.ErrNotExist, err)
+	}
+
+	writeTestSystemFiles(t, procRoot)
+
+	got, err := GetHostname(procRoot)
+	if err != nil {
+		t.Fatalf("failed to read hostname: %v", err)
+	}
+
+	if want := "node1.example.com"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
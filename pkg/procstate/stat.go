@@ -0,0 +1,210 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ProcStat provides select values from a /proc/[pid]/stat file. Unlike the
+// status file, the stat file is a single line of space separated values
+// intended for programmatic use.
+//
+// https://man7.org/linux/man-pages/man5/proc_pid_stat.5.html
+type ProcStat struct {
+	// Pid is the process ID (field 1).
+	Pid int
+
+	// Comm is the filename of the executable (field 2) without the
+	// enclosing parentheses.
+	Comm string
+
+	// State is the single character process state code (field 3).
+	State string
+
+	// PPid is the process ID of the parent process (field 4).
+	PPid int
+
+	// Flags is the kernel flags word of the process (field 9). See the PF_*
+	// defines in the kernel source file include/linux/sched.h.
+	Flags uint
+
+	// UTime is the time the process has been scheduled in user mode in
+	// clock ticks (field 14).
+	UTime uint64
+
+	// STime is the time the process has been scheduled in kernel mode in
+	// clock ticks (field 15).
+	STime uint64
+
+	// StartTime is the time the process started after system boot in clock
+	// ticks (field 22). Together with the process ID this value uniquely
+	// identifies a process as process ID values are reused.
+	StartTime uint64
+}
+
+// ClockTicksToDuration converts the specified number of clock ticks (e.g.,
+// the StartTime or CPUTime values of a process) to a time.Duration value.
+func ClockTicksToDuration(ticks uint64) time.Duration {
+	seconds := ticks / ClockTicksPerSecond
+	remainder := ticks % ClockTicksPerSecond
+
+	return time.Duration(seconds)*time.Second +
+		time.Duration(remainder)*time.Second/time.Duration(ClockTicksPerSecond)
+}
+
+// ParseProcStatFile parses a given /proc/[pid]/stat file and returns a
+// ProcStat value representing select values for a process.
+func ParseProcStatFile(filename string) (ProcStat, error) {
+	data, err := os.ReadFile(filepath.Clean(filename))
+	if err != nil {
+		return ProcStat{},
+			fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	stat, err := parseProcStat(data)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Path = filename
+
+			return ProcStat{}, err
+		}
+
+		return ProcStat{}, fmt.Errorf(
+			"failed to obtain stat values for process from file %s: %w",
+			filename,
+			err,
+		)
+	}
+
+	return stat, nil
+}
+
+// parseProcStat is responsible for processing a stat file's content and
+// generating a ProcStat value.
+func parseProcStat(data []byte) (ProcStat, error) {
+
+	// The comm field is enclosed in parentheses and may contain spaces or
+	// parentheses of its own (e.g., 'kworker/0:1H-kblockd' or 'a) b'). The
+	// kernel does not escape this field so we use the first opening and the
+	// last closing parenthesis as the field boundaries.
+	open := bytes.IndexByte(data, '(')
+	closing := bytes.LastIndexByte(data, ')')
+	if open < 0 || closing < open {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "comm",
+			Value: string(bytes.TrimSpace(data)),
+			Err:   ErrInvalidProcStatLineFormat,
+		}
+	}
+
+	var stat ProcStat
+
+	pid, err := strconv.Atoi(string(bytes.TrimSpace(data[:open])))
+	if err != nil {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "pid",
+			Value: string(bytes.TrimSpace(data[:open])),
+			Err:   ErrInvalidProcStatLineValue,
+		}
+	}
+	stat.Pid = pid
+	stat.Comm = string(data[open+1 : closing])
+
+	// Fields following the comm field, starting with state (field 3).
+	fields := bytes.Fields(data[closing+1:])
+
+	const (
+		stateIdx     = 0  // field 3
+		ppidIdx      = 1  // field 4
+		flagsIdx     = 6  // field 9
+		utimeIdx     = 11 // field 14
+		stimeIdx     = 12 // field 15
+		startTimeIdx = 19 // field 22
+	)
+
+	if len(fields) <= startTimeIdx {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Value: string(bytes.TrimSpace(data)),
+			Err: fmt.Errorf(
+				"got %d fields after comm: %w",
+				len(fields),
+				ErrInvalidProcStatLineFormat,
+			),
+		}
+	}
+
+	stat.State = string(fields[stateIdx])
+
+	ppid, err := strconv.Atoi(string(fields[ppidIdx]))
+	if err != nil {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "ppid",
+			Value: string(fields[ppidIdx]),
+			Err:   ErrInvalidProcStatLineValue,
+		}
+	}
+	stat.PPid = ppid
+
+	flags, err := strconv.ParseUint(string(fields[flagsIdx]), 10, 0)
+	if err != nil {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "flags",
+			Value: string(fields[flagsIdx]),
+			Err:   ErrInvalidProcStatLineValue,
+		}
+	}
+	stat.Flags = uint(flags)
+
+	utime, err := strconv.ParseUint(string(fields[utimeIdx]), 10, 64)
+	if err != nil {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "utime",
+			Value: string(fields[utimeIdx]),
+			Err:   ErrInvalidProcStatLineValue,
+		}
+	}
+	stat.UTime = utime
+
+	stime, err := strconv.ParseUint(string(fields[stimeIdx]), 10, 64)
+	if err != nil {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "stime",
+			Value: string(fields[stimeIdx]),
+			Err:   ErrInvalidProcStatLineValue,
+		}
+	}
+	stat.STime = stime
+
+	startTime, err := strconv.ParseUint(string(fields[startTimeIdx]), 10, 64)
+	if err != nil {
+		return ProcStat{}, &ParseError{
+			Line:  1,
+			Key:   "starttime",
+			Value: string(fields[startTimeIdx]),
+			Err:   ErrInvalidProcStatLineValue,
+		}
+	}
+	stat.StartTime = startTime
+
+	return stat, nil
+}
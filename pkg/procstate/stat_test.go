@@ -0,0 +1,80 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"errors"
+	"testing"
+)
+
+// FuzzParseStat asserts that parsing arbitrary stat file content does not
+// panic and that any returned parse errors wrap a known error.
+func FuzzParseStat(f *testing.F) {
+	addSeedFiles(f, "testdata/stat/*.stat")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, err := parseProcStat(data)
+		if err != nil {
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) && parseErr.Unwrap() == nil {
+				t.Errorf("parse error %q does not wrap an error", err)
+			}
+		}
+	})
+}
+
+// FuzzParseStatComm asserts that the comm field is retained as-is when
+// parsing stat file content. Unlike the status file, the kernel does not
+// escape this field.
+func FuzzParseStatComm(f *testing.F) {
+	for _, comm := range []string{
+		"bash",
+		"flush-253:0",
+		"kworker/0:1H",
+		"Web Content",
+		"a) b",
+		"a) S 1 2 3",
+		"(sd-pam)",
+		"new\nline",
+		"",
+	} {
+		f.Add(comm)
+	}
+
+	f.Fuzz(func(t *testing.T, comm string) {
+		// Process names are limited in length by the kernel.
+		if len(comm) > 64 {
+			t.Skip()
+		}
+
+		data := []byte("7702 (" + comm + ") S 7613 7487 7487 0 -1 4194560 402116 0 3 0 41232 9812 0 0 20 0 27 0 88123\n")
+
+		stat, err := parseProcStat(data)
+		if err != nil {
+			t.Fatalf("failed to parse stat content for comm %q: %v", comm, err)
+		}
+
+		if stat.Comm != comm {
+			t.Errorf("\nwant comm %q\ngot comm %q", comm, stat.Comm)
+		}
+
+		if stat.Pid != 7702 || stat.PPid != 7613 || stat.Flags != 4194560 ||
+			stat.UTime != 41232 || stat.STime != 9812 || stat.StartTime != 88123 {
+			t.Errorf(
+				"unexpected values for comm %q: pid %d, ppid %d, flags %d, utime %d, stime %d, starttime %d",
+				comm,
+				stat.Pid,
+				stat.PPid,
+				stat.Flags,
+				stat.UTime,
+				stat.STime,
+				stat.StartTime,
+			)
+		}
+	})
+}
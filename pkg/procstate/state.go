@@ -0,0 +1,382 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// StateCategory is the normalized category of a process state. Process
+// states which differ only by kernel version (e.g., "T (tracing stop)" on a
+// 2.6.32 kernel and "t (tracing stop)" on newer kernels) share a category.
+// The category value is also used as the performance data label for the
+// number of processes in that category.
+type StateCategory string
+
+// Process state categories.
+const (
+	StateCategoryRunning     StateCategory = "running"
+	StateCategorySleeping    StateCategory = "sleeping"
+	StateCategoryDiskSleep   StateCategory = "uninterruptible_disk_sleep"
+	StateCategoryStopped     StateCategory = "stopped"
+	StateCategoryZombie      StateCategory = "zombie"
+	StateCategoryDead        StateCategory = "dead"
+	StateCategoryTracingStop StateCategory = "tracing_stop"
+	StateCategoryWakeKill    StateCategory = "wakekill"
+	StateCategoryWaking      StateCategory = "waking"
+	StateCategoryIdle        StateCategory = "idle"
+	StateCategoryParked      StateCategory = "parked"
+
+	// StateCategoryUnknown is used for process states not known to this
+	// package, including malformed state values.
+	StateCategoryUnknown StateCategory = "unknown"
+)
+
+// Severity indicates how problematic a process state is considered.
+type Severity int
+
+// Process state severity levels.
+const (
+	SeverityOK Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// Kernel versions used as the baseline for process state values. These are
+// the kernel versions of the RHEL releases this project was tested against.
+const (
+	KernelGeneration2632 string = "2.6.32" // RHEL 6
+	KernelGeneration310  string = "3.10"   // RHEL 7
+	KernelGeneration418  string = "4.18"   // RHEL 8
+	KernelGeneration514  string = "5.14"   // RHEL 9
+)
+
+// ProcessState is a process state as reported by the State field of a
+// /proc/[pid]/status file.
+//
+// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/fs/proc/array.c?h=v2.6.32#n136
+// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/fs/proc/array.c?h=v3.10#n135
+// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/fs/proc/array.c?h=v4.18#n130
+// https://git.kernel.org/pub/scm/linux/kernel/git/torvalds/linux.git/tree/fs/proc/array.c?h=v5.14#n130
+type ProcessState struct {
+	// Code is the single letter state code (e.g., 'R'). This value is zero
+	// for malformed state values.
+	Code byte
+
+	// Description is the description of the state as provided by the kernel
+	// (e.g., "running"). For malformed state values this is the original
+	// value as-is.
+	Description string
+
+	// Kernels is the list of baseline kernel versions observed to emit the
+	// state. This list is empty for states not known to this package.
+	Kernels []string
+
+	// Category is the normalized category of the state.
+	Category StateCategory
+
+	// Severity indicates how problematic the state is considered.
+	Severity Severity
+
+	// Explanation is a plain-language explanation of the state and why it is
+	// (or is not) considered a problem. This value is empty for states not
+	// known to this package.
+	Explanation string
+}
+
+// Process state values observed for 2.6.32, 3.10, 4.18 & 5.14 kernels and
+// presumed to be stable enough to be used by future kernels.
+var (
+	KernelAnyProcessStateRunning = ProcessState{
+		Code:        'R',
+		Description: "running",
+		Kernels:     []string{KernelGeneration2632, KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryRunning,
+		Explanation: "Running on a CPU or waiting in a run queue for its turn. This is normal for any process doing work.",
+	}
+
+	KernelAnyProcessStateSleeping = ProcessState{
+		Code:        'S',
+		Description: "sleeping",
+		Kernels:     []string{KernelGeneration2632, KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategorySleeping,
+		Explanation: "Interruptible sleep while waiting for an event such as I/O readiness, a timer or a signal. Most processes spend most of their time in this state.",
+	}
+
+	KernelAnyProcessStateDiskSleep = ProcessState{
+		Code:        'D',
+		Description: "disk sleep",
+		Kernels:     []string{KernelGeneration2632, KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryDiskSleep,
+		Severity:    SeverityCritical,
+		Explanation: "Uninterruptible sleep, usually while waiting on storage or a network filesystem. Signals (including SIGKILL) are ignored until the wait completes. A process which remains in this state usually indicates failing storage, a hung network mount or a kernel bug and often requires a reboot to clear.",
+	}
+
+	KernelAnyProcessStateStopped = ProcessState{
+		Code:        'T',
+		Description: "stopped",
+		Kernels:     []string{KernelGeneration2632, KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryStopped,
+		Explanation: "Stopped by a job control signal (e.g., SIGSTOP or Ctrl-Z) and resumed by SIGCONT. This is usually intentional.",
+	}
+
+	KernelAnyProcessStateZombie = ProcessState{
+		Code:        'Z',
+		Description: "zombie",
+		Kernels:     []string{KernelGeneration2632, KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryZombie,
+		Severity:    SeverityWarning,
+		Explanation: "Terminated but not yet reaped by the parent process. A zombie holds no memory or other resources beyond its process table entry and is removed once the parent reaps it or exits. A growing number of zombies indicates a parent process which is not reaping its children.",
+	}
+
+	KernelAnyProcessStateDead = ProcessState{
+		Code:        'X',
+		Description: "dead",
+		Kernels:     []string{KernelGeneration2632, KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryDead,
+		Explanation: "Terminated and in the process of being removed. This state is transient and is rarely observed.",
+	}
+)
+
+// Process state values observed for legacy kernels that are not present on
+// current/modern kernel versions.
+var (
+	KernelLegacyProcessStateTracingStop = ProcessState{
+		Code:        'T',
+		Description: "tracing stop",
+		Kernels:     []string{KernelGeneration2632},
+		Category:    StateCategoryTracingStop,
+		Explanation: "Stopped by a debugger or tracer (e.g., gdb or strace). Older kernels report this state using the same code as processes stopped by job control.",
+	}
+
+	KernelLegacyProcessStateDead = ProcessState{
+		Code:        'x',
+		Description: "dead",
+		Kernels:     []string{KernelGeneration310},
+		Category:    StateCategoryDead,
+		Explanation: "Terminated and in the process of being removed. Older kernels report this transient state using a lowercase code.",
+	}
+
+	KernelLegacyProcessStateWakeKill = ProcessState{
+		Code:        'K',
+		Description: "wakekill",
+		Kernels:     []string{KernelGeneration310},
+		Category:    StateCategoryWakeKill,
+		Explanation: "Sleeping, but may be woken by fatal signals. Older kernels report this as a separate state; newer kernels report these processes as being in disk sleep.",
+	}
+
+	KernelLegacyProcessStateWaking = ProcessState{
+		Code:        'W',
+		Description: "waking",
+		Kernels:     []string{KernelGeneration310},
+		Category:    StateCategoryWaking,
+		Explanation: "In the process of being woken up. This state is transient and is rarely observed.",
+	}
+)
+
+// Process state values observed for newer kernel versions and presumed to be
+// stable enough to be used by future kernels.
+var (
+	KernelCurrentProcessStateTracingStop = ProcessState{
+		Code:        't',
+		Description: "tracing stop",
+		Kernels:     []string{KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryTracingStop,
+		Explanation: "Stopped by a debugger or tracer (e.g., gdb or strace) and resumed when the tracer allows it.",
+	}
+
+	KernelCurrentProcessStateIdle = ProcessState{
+		Code:        'I',
+		Description: "idle",
+		Kernels:     []string{KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryIdle,
+		Explanation: "Idle kernel thread waiting for work. Newer kernels report idle kernel threads separately so they are not mistaken for processes in disk sleep.",
+	}
+
+	KernelCurrentProcessStateParked = ProcessState{
+		Code:        'P',
+		Description: "parked",
+		Kernels:     []string{KernelGeneration310, KernelGeneration418, KernelGeneration514},
+		Category:    StateCategoryParked,
+		Explanation: "Kernel thread parked by the kernel, usually a per-CPU thread for a CPU which is offline.",
+	}
+)
+
+// knownProcessStates is the table of process states known to this package.
+// Collection filtering, severity evaluation, performance data labels and
+// report grouping are all driven by this table. The order of entries
+// determines the order of state categories in output.
+var knownProcessStates = []ProcessState{
+	KernelAnyProcessStateRunning,
+	KernelAnyProcessStateSleeping,
+	KernelAnyProcessStateDiskSleep,
+	KernelAnyProcessStateStopped,
+	KernelAnyProcessStateZombie,
+	KernelAnyProcessStateDead,
+	KernelLegacyProcessStateTracingStop,
+	KernelLegacyProcessStateDead,
+	KernelLegacyProcessStateWakeKill,
+	KernelLegacyProcessStateWaking,
+	KernelCurrentProcessStateTracingStop,
+	KernelCurrentProcessStateIdle,
+	KernelCurrentProcessStateParked,
+}
+
+// ParseProcessState parses the given State field value from a
+// /proc/[pid]/status file (e.g., "S (sleeping)") and returns the matching
+// known ProcessState. A ProcessState with the StateCategoryUnknown category
+// is returned for state values not known to this package; if the value is
+// malformed the Code field is zero and the value is retained as-is in the
+// Description field.
+func ParseProcessState(value string) ProcessState {
+	value = strings.TrimSpace(value)
+
+	for _, state := range knownProcessStates {
+		if value == state.String() {
+			return state
+		}
+	}
+
+	// Expected format is a single letter code followed by a description
+	// enclosed in parentheses.
+	if len(value) > 4 && value[1] == ' ' && value[2] == '(' && value[len(value)-1] == ')' {
+		return ProcessState{
+			Code:        value[0],
+			Description: value[3 : len(value)-1],
+			Category:    StateCategoryUnknown,
+		}
+	}
+
+	return ProcessState{
+		Description: value,
+		Category:    StateCategoryUnknown,
+	}
+}
+
+// String provides the process state in the same format used by the
+// /proc/[pid]/status file (e.g., "S (sleeping)").
+func (s ProcessState) String() string {
+	if s.Code == 0 {
+		return s.Description
+	}
+
+	return fmt.Sprintf("%c (%s)", s.Code, s.Description)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. The process
+// state is encoded in the same format used by the /proc/[pid]/status file.
+func (s ProcessState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The
+// process state is parsed using ParseProcessState.
+func (s *ProcessState) UnmarshalText(text []byte) error {
+	*s = ParseProcessState(string(text))
+
+	return nil
+}
+
+// IsKnown indicates whether the process state is known to this package.
+func (s ProcessState) IsKnown() bool {
+	return s.Category != StateCategoryUnknown && s.Category != ""
+}
+
+// Equal indicates whether the process state is equivalent to the given
+// process state. Known process states are equivalent if they share a
+// category (e.g., "T (tracing stop)" and "t (tracing stop)"). Unknown
+// process states are equivalent if their values are identical.
+func (s ProcessState) Equal(other ProcessState) bool {
+	if s.IsKnown() || other.IsKnown() {
+		return s.Category == other.Category
+	}
+
+	return s.String() == other.String()
+}
+
+// EmittedBy indicates whether the process state is emitted by the given
+// baseline kernel version.
+func (s ProcessState) EmittedBy(kernel string) bool {
+	for _, k := range s.Kernels {
+		if k == kernel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ServiceState returns the Service Check Status label and exit code
+// associated with the process state severity.
+func (s ProcessState) ServiceState() nagios.ServiceState {
+	return s.Severity.ServiceState()
+}
+
+// String provides a human readable severity label.
+func (sev Severity) String() string {
+	return sev.ServiceState().Label
+}
+
+// ServiceState returns the Service Check Status label and exit code
+// associated with the severity.
+func (sev Severity) ServiceState() nagios.ServiceState {
+	switch sev {
+	case SeverityCritical:
+		return nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		}
+	case SeverityWarning:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+	default:
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+	}
+}
+
+// StateCategories returns the list of known process state categories in
+// the order used for output.
+func StateCategories() []StateCategory {
+	categories := make([]StateCategory, 0, len(knownProcessStates))
+	seen := make(map[StateCategory]struct{}, len(knownProcessStates))
+	for _, state := range knownProcessStates {
+		if _, ok := seen[state.Category]; ok {
+			continue
+		}
+		seen[state.Category] = struct{}{}
+		categories = append(categories, state.Category)
+	}
+
+	return categories
+}
+
+// PerfDataLabel returns the performance data label used for the number of
+// processes in the state category.
+func (c StateCategory) PerfDataLabel() string {
+	return string(c)
+}
+
+// rank returns the position of the process state category within the
+// table of known process states. Unknown states are ranked last.
+func (s ProcessState) rank() int {
+	for i, state := range knownProcessStates {
+		if state.Category == s.Category {
+			return i
+		}
+	}
+
+	return len(knownProcessStates)
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// evaluatedProperties is the list of /proc/[pid]/status properties retained
+// when parsing status files selectively.
+var evaluatedProperties = []string{
+	ProcessNameField,
+	ProcessStateField,
+	ProcessPidField,
+	ProcessPPidField,
+	ProcessThreadsField,
+	ProcessVMSwapField,
+	ProcessVMRSSField,
+	ProcessUIDField,
+}
+
+// evaluatedPropertyKeys is the list of evaluatedProperties values as byte
+// slices for allocation-free comparison against status file keys.
+var evaluatedPropertyKeys = func() [][]byte {
+	keys := make([][]byte, 0, len(evaluatedProperties))
+	for _, property := range evaluatedProperties {
+		keys = append(keys, []byte(property))
+	}
+
+	return keys
+}()
+
+// EvaluatedProperties returns the list of /proc/[pid]/status properties
+// retained when parsing status files selectively. These are the properties
+// used to set the "primary" fields of a Process value and to evaluate the
+// process (e.g., ignore rules).
+func EvaluatedProperties() []string {
+	return append([]string(nil), evaluatedProperties...)
+}
+
+// statusBufferSize is the initial size of buffers used to read status
+// files. This is large enough for a typical status file to be read using a
+// single buffer without growing it.
+const statusBufferSize int = 4096
+
+// statusBufferMaxSize is the largest buffer returned to the pool of status
+// file buffers. Buffers grown beyond this size while reading an unusually
+// large status file are discarded instead of being retained by the pool.
+const statusBufferMaxSize int = 64 * 1024
+
+// statusBufferPool is a pool of buffers used to read status files. Values
+// retained from a status file are copied from the buffer before it is
+// returned to the pool.
+var statusBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, statusBufferSize)
+		return &buf
+	},
+}
+
+// ParseProcStatusFileSelective parses a given /proc/[pid]/status file and
+// returns a Process value representing the status of a process. Unlike
+// ParseProcStatusFile, the file content is scanned directly and only the
+// properties listed in EvaluatedProperties are retained in the
+// AllProperties index. This significantly reduces the time spent and memory
+// allocated when evaluating a large number of processes.
+func ParseProcStatusFileSelective(filename string) (Process, error) {
+	bufPtr, ok := statusBufferPool.Get().(*[]byte)
+	if !ok {
+		buf := make([]byte, 0, statusBufferSize)
+		bufPtr = &buf
+	}
+	defer func() {
+		if cap(*bufPtr) <= statusBufferMaxSize {
+			statusBufferPool.Put(bufPtr)
+		}
+	}()
+
+	data, err := readFileInto(filename, (*bufPtr)[:0])
+	*bufPtr = data[:0]
+	if err != nil {
+		return Process{},
+			fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	p, err := parseStatusSelective(data)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Path = filename
+
+			return Process{}, err
+		}
+
+		return Process{}, fmt.Errorf(
+			"failed to obtain properties for process from file %s: %w",
+			filename,
+			err,
+		)
+	}
+
+	return p, nil
+}
+
+// readFileInto reads the content of the specified file, appending it to the
+// given buffer. The buffer is grown as needed and returned.
+func readFileInto(filename string, buf []byte) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return buf, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+
+		n, err := f.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return buf, nil
+			}
+
+			return buf, err
+		}
+	}
+}
+
+// parseStatusSelective is responsible for processing a status file's content
+// and generating a Process value. Only the properties listed in
+// EvaluatedProperties are retained. Parsing rules match those of
+// getProcessProperties.
+func parseStatusSelective(data []byte) (Process, error) {
+	var process Process
+	process.AllProperties = make(Properties, len(evaluatedProperties))
+	lineNumbers := make(map[string]int, len(evaluatedProperties))
+
+	var lineNum int
+	for len(data) > 0 {
+		lineNum++
+
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+
+		rawKey, rawValue, _ := bytes.Cut(line, []byte(":"))
+
+		key := bytes.TrimSpace(rawKey)
+		if len(key) == 0 {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			return Process{}, &ParseError{
+				Line:  lineNum,
+				Value: string(line),
+				Err:   ErrInvalidProcStatusLineFormat,
+			}
+		}
+
+		idx := -1
+		for i, k := range evaluatedPropertyKeys {
+			if bytes.EqualFold(key, k) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			continue
+		}
+
+		property := evaluatedProperties[idx]
+
+		var value []byte
+		switch property {
+		case ProcessNameField:
+			// See getProcessProperties for details.
+			value = bytes.TrimPrefix(rawValue, []byte("\t"))
+		default:
+			value = bytes.TrimSpace(rawValue)
+		}
+
+		process.AllProperties[property] = string(value)
+		lineNumbers[property] = lineNum
+	}
+
+	if err := process.setProcessPropsWithLines(lineNumbers); err != nil {
+		return Process{}, err
+	}
+
+	return process, nil
+}
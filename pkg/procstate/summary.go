@@ -0,0 +1,261 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// StateTally is the number of processes found in a specific process state.
+type StateTally struct {
+	// State is the process state in the same format used by the
+	// /proc/[pid]/status file (e.g., "S (sleeping)").
+	State string `json:"state"`
+
+	// Category is the normalized category of the process state.
+	Category StateCategory `json:"category"`
+
+	// Count is the number of processes found in the process state.
+	Count int `json:"count"`
+}
+
+// CategoryTally is the number of processes found with a state in a specific
+// process state category.
+type CategoryTally struct {
+	// Category is the process state category.
+	Category StateCategory `json:"category"`
+
+	// Count is the number of processes found with a state in the category.
+	Count int `json:"count"`
+}
+
+// SummaryOptions is the collection of optional settings used to generate a
+// Summary for a collection of processes.
+type SummaryOptions struct {
+	// Ignored is the collection of problem processes ignored by
+	// user-specified rules. Ignored processes are counted, but are not
+	// otherwise evaluated.
+	Ignored Processes
+
+	// Unexpected is the collection of processes in states not expected for
+	// the running kernel.
+	Unexpected Processes
+
+	// UnexpectedStateSeverity is the service state applied if any processes
+	// are found in unexpected states.
+	UnexpectedStateSeverity nagios.ServiceState
+}
+
+// Summary is the structured summary of the evaluation results for a
+// collection of processes. Human readable output (reports), performance data
+// and machine readable output are all generated from this type so that they
+// remain consistent with each other.
+type Summary struct {
+	// States is the number of processes found in each observed process
+	// state, ordered by process state value.
+	States []StateTally `json:"states"`
+
+	// Categories is the number of processes found in each known process
+	// state category, ordered as listed in the table of known process
+	// states. Categories without any processes are included.
+	Categories []CategoryTally `json:"categories"`
+
+	// Total is the number of evaluated processes.
+	Total int `json:"total"`
+
+	// Problem is the number of processes in a WARNING or CRITICAL state.
+	Problem int `json:"problem"`
+
+	// Warning is the number of processes in a WARNING state.
+	Warning int `json:"warning"`
+
+	// Critical is the number of processes in a CRITICAL state.
+	Critical int `json:"critical"`
+
+	// OK is the number of processes in an OK state.
+	OK int `json:"ok"`
+
+	// KernelThreadProblem is the number of problem processes which are
+	// kernel threads.
+	KernelThreadProblem int `json:"kernel_thread_problem"`
+
+	// UserlandProblem is the number of problem processes which are not
+	// kernel threads.
+	UserlandProblem int `json:"userland_problem"`
+
+	// KernelBlocked is the number of processes reported as blocked by the
+	// kernel hung task detector.
+	KernelBlocked int `json:"kernel_blocked"`
+
+	// Unexpected is the number of processes in states not expected for the
+	// running kernel.
+	Unexpected int `json:"unexpected"`
+
+	// Ignored is the number of problem processes ignored by user-specified
+	// rules.
+	Ignored int `json:"ignored"`
+
+	// ServiceStateLabel is the Service Check Status label for the
+	// evaluation results (e.g., "WARNING").
+	ServiceStateLabel string `json:"service_state"`
+
+	// ServiceStateExitCode is the Service Check Status exit code for the
+	// evaluation results.
+	ServiceStateExitCode int `json:"exit_code"`
+}
+
+// SummaryBuilder accumulates the values needed to generate a Summary one
+// Process at a time. This allows a Summary to be generated without retaining
+// the evaluated processes (e.g., when iterating over processes as they are
+// read). The zero value is ready for use.
+type SummaryBuilder struct {
+	summary    Summary
+	states     map[string]StateTally
+	categories map[StateCategory]int
+}
+
+// Add includes the specified Process in the summary.
+func (b *SummaryBuilder) Add(p Process) {
+	if b.states == nil {
+		b.states = make(map[string]StateTally)
+		b.categories = make(map[StateCategory]int)
+	}
+
+	b.summary.Total++
+
+	key := p.State.String()
+	tally := b.states[key]
+	tally.State = key
+	tally.Category = p.State.Category
+	tally.Count++
+	b.states[key] = tally
+
+	b.categories[p.State.Category]++
+
+	switch p.State.Severity {
+	case SeverityCritical:
+		b.summary.Critical++
+	case SeverityWarning:
+		b.summary.Warning++
+	default:
+		b.summary.OK++
+	}
+
+	if p.State.Severity != SeverityOK {
+		b.summary.Problem++
+		if p.IsKernelThread() {
+			b.summary.KernelThreadProblem++
+		} else {
+			b.summary.UserlandProblem++
+		}
+	}
+
+	if p.KernelBlockedSeconds > 0 {
+		b.summary.KernelBlocked++
+	}
+}
+
+// Summary generates a structured summary of the evaluation results for all
+// processes added so far using the specified options.
+func (b *SummaryBuilder) Summary(opts SummaryOptions) Summary {
+	summary := b.summary
+	summary.Unexpected = len(opts.Unexpected)
+	summary.Ignored = len(opts.Ignored)
+
+	summary.States = make([]StateTally, 0, len(b.states))
+	for _, tally := range b.states {
+		summary.States = append(summary.States, tally)
+	}
+	sort.Slice(summary.States, func(i, j int) bool {
+		return summary.States[i].State < summary.States[j].State
+	})
+
+	categories := StateCategories()
+	summary.Categories = make([]CategoryTally, 0, len(categories))
+	for _, category := range categories {
+		summary.Categories = append(summary.Categories, CategoryTally{
+			Category: category,
+			Count:    b.categories[category],
+		})
+	}
+
+	var serviceState nagios.ServiceState
+	switch {
+	case summary.Critical > 0:
+		serviceState = SeverityCritical.ServiceState()
+	case summary.Warning > 0:
+		serviceState = SeverityWarning.ServiceState()
+	default:
+		serviceState = SeverityOK.ServiceState()
+	}
+
+	if summary.Unexpected > 0 {
+		serviceState = WorstServiceState(serviceState, opts.UnexpectedStateSeverity)
+	}
+	summary.ServiceStateLabel = serviceState.Label
+	summary.ServiceStateExitCode = serviceState.ExitCode
+
+	return summary
+}
+
+// Summary generates a structured summary of the evaluation results for the
+// collection using the specified options.
+func (ps Processes) Summary(opts SummaryOptions) Summary {
+	var builder SummaryBuilder
+	for _, p := range ps {
+		builder.Add(p)
+	}
+
+	return builder.Summary(opts)
+}
+
+// ServiceState returns the Service Check Status label and exit code for the
+// evaluation results.
+func (s Summary) ServiceState() nagios.ServiceState {
+	return nagios.ServiceState{
+		Label:    s.ServiceStateLabel,
+		ExitCode: s.ServiceStateExitCode,
+	}
+}
+
+// IsOKState indicates whether all evaluated processes are in an OK state.
+func (s Summary) IsOKState() bool {
+	return s.Problem == 0
+}
+
+// CategoryCount returns the number of processes with a state in the
+// specified state category.
+func (s Summary) CategoryCount(category StateCategory) int {
+	for _, tally := range s.Categories {
+		if tally.Category == category {
+			return tally.Count
+		}
+	}
+
+	return 0
+}
+
+// StateList returns a slice of formatted process state summary items (e.g.,
+// "S (sleeping) [123]").
+func (s Summary) StateList() []string {
+	items := make([]string, 0, len(s.States))
+	for _, tally := range s.States {
+		items = append(items, fmt.Sprintf("%s [%d]", tally.State, tally.Count))
+	}
+
+	return items
+}
+
+// OneLine returns a one line summary of the process states.
+func (s Summary) OneLine() string {
+	return strings.Join(s.StateList(), ", ")
+}
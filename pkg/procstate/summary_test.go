@@ -0,0 +1,216 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// TestSummary asserts that processes are tallied by state, category and
+// severity, that problem processes are split between kernel threads and
+// userland processes and that the service state accounts for unexpected
+// states.
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	sleeping := Process{Name: "bash", Pid: 100, PPid: 1, State: KernelAnyProcessStateSleeping}
+	running := Process{Name: "top", Pid: 110, PPid: 100, State: KernelAnyProcessStateRunning}
+	diskSleep := Process{Name: "java", Pid: 200, PPid: 1, State: KernelAnyProcessStateDiskSleep}
+	blocked := Process{Name: "rsync", Pid: 210, PPid: 1, State: KernelAnyProcessStateDiskSleep, KernelBlockedSeconds: 120}
+	kthreadDiskSleep := Process{Name: "jbd2/dm-0-8", Pid: 300, PPid: KThreaddPID, State: KernelAnyProcessStateDiskSleep}
+	flaggedDiskSleep := Process{Name: "nfsd", Pid: 310, PPid: 1, Flags: PFKThread, State: KernelAnyProcessStateDiskSleep}
+	zombie := Process{Name: "defunct", Pid: 400, PPid: 100, State: KernelAnyProcessStateZombie}
+
+	warning := nagios.ServiceState{Label: nagios.StateWARNINGLabel, ExitCode: nagios.StateWARNINGExitCode}
+	critical := nagios.ServiceState{Label: nagios.StateCRITICALLabel, ExitCode: nagios.StateCRITICALExitCode}
+	ok := nagios.ServiceState{Label: nagios.StateOKLabel, ExitCode: nagios.StateOKExitCode}
+
+	// counts holds the evaluated Summary values, including the per-category
+	// counts of the categories used by the test processes.
+	type counts struct {
+		Total, Problem, Warning, Critical, OK int
+		KernelThreadProblem, UserlandProblem  int
+		KernelBlocked, Unexpected, Ignored    int
+		DiskSleep, Sleeping, Zombie, Running  int
+		IsOKState                             bool
+		ServiceState                          nagios.ServiceState
+		StateList                             []string
+	}
+
+	tests := map[string]struct {
+		processes Processes
+		opts      SummaryOptions
+		want      counts
+	}{
+		"empty": {
+			want: counts{
+				IsOKState:    true,
+				ServiceState: ok,
+				StateList:    []string{},
+			},
+		},
+		"ok states only": {
+			processes: Processes{sleeping, running, sleeping},
+			want: counts{
+				Total: 3, OK: 3,
+				Sleeping: 2, Running: 1,
+				IsOKState:    true,
+				ServiceState: ok,
+				StateList:    []string{"R (running) [1]", "S (sleeping) [2]"},
+			},
+		},
+		"warning state": {
+			processes: Processes{sleeping, zombie},
+			want: counts{
+				Total: 2, Problem: 1, Warning: 1, OK: 1,
+				UserlandProblem: 1,
+				Sleeping:        1, Zombie: 1,
+				ServiceState: warning,
+				StateList:    []string{"S (sleeping) [1]", "Z (zombie) [1]"},
+			},
+		},
+		"critical and warning states": {
+			processes: Processes{zombie, diskSleep, sleeping},
+			want: counts{
+				Total: 3, Problem: 2, Warning: 1, Critical: 1, OK: 1,
+				UserlandProblem: 2,
+				DiskSleep:       1, Sleeping: 1, Zombie: 1,
+				ServiceState: critical,
+				StateList:    []string{"D (disk sleep) [1]", "S (sleeping) [1]", "Z (zombie) [1]"},
+			},
+		},
+		"kernel thread and userland problems": {
+			processes: Processes{kthreadDiskSleep, flaggedDiskSleep, diskSleep, blocked},
+			want: counts{
+				Total: 4, Problem: 4, Critical: 4,
+				KernelThreadProblem: 2, UserlandProblem: 2,
+				KernelBlocked: 1,
+				DiskSleep:     4,
+				ServiceState:  critical,
+				StateList:     []string{"D (disk sleep) [4]"},
+			},
+		},
+		"unexpected states raise service state": {
+			processes: Processes{sleeping},
+			opts: SummaryOptions{
+				Unexpected:              Processes{sleeping},
+				UnexpectedStateSeverity: warning,
+			},
+			want: counts{
+				Total: 1, OK: 1, Unexpected: 1,
+				Sleeping:     1,
+				IsOKState:    true,
+				ServiceState: warning,
+				StateList:    []string{"S (sleeping) [1]"},
+			},
+		},
+		"unexpected states do not lower service state": {
+			processes: Processes{diskSleep},
+			opts: SummaryOptions{
+				Unexpected:              Processes{diskSleep},
+				UnexpectedStateSeverity: warning,
+			},
+			want: counts{
+				Total: 1, Problem: 1, Critical: 1, Unexpected: 1,
+				UserlandProblem: 1,
+				DiskSleep:       1,
+				ServiceState:    critical,
+				StateList:       []string{"D (disk sleep) [1]"},
+			},
+		},
+		"ignored processes counted": {
+			processes: Processes{sleeping},
+			opts: SummaryOptions{
+				Ignored: Processes{diskSleep, zombie},
+			},
+			want: counts{
+				Total: 1, OK: 1, Ignored: 2,
+				Sleeping:     1,
+				IsOKState:    true,
+				ServiceState: ok,
+				StateList:    []string{"S (sleeping) [1]"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			summary := tt.processes.Summary(tt.opts)
+
+			got := counts{
+				Total:               summary.Total,
+				Problem:             summary.Problem,
+				Warning:             summary.Warning,
+				Critical:            summary.Critical,
+				OK:                  summary.OK,
+				KernelThreadProblem: summary.KernelThreadProblem,
+				UserlandProblem:     summary.UserlandProblem,
+				KernelBlocked:       summary.KernelBlocked,
+				Unexpected:          summary.Unexpected,
+				Ignored:             summary.Ignored,
+				DiskSleep:           summary.CategoryCount(StateCategoryDiskSleep),
+				Sleeping:            summary.CategoryCount(StateCategorySleeping),
+				Zombie:              summary.CategoryCount(StateCategoryZombie),
+				Running:             summary.CategoryCount(StateCategoryRunning),
+				IsOKState:           summary.IsOKState(),
+				ServiceState:        summary.ServiceState(),
+				StateList:           summary.StateList(),
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("\nwant %+v\ngot  %+v", tt.want, got)
+			}
+
+			// Every known category is listed, even without processes.
+			if len(summary.Categories) != len(StateCategories()) {
+				t.Errorf(
+					"want %d categories, got %d",
+					len(StateCategories()),
+					len(summary.Categories),
+				)
+			}
+
+			// The problem counts agree with the collection evaluation.
+			if summary.IsOKState() != tt.processes.IsOKState() {
+				t.Errorf(
+					"want IsOKState %t matching the collection, got %t",
+					tt.processes.IsOKState(),
+					summary.IsOKState(),
+				)
+			}
+		})
+	}
+}
+
+// TestSummaryBuilder asserts that a Summary generated one Process at a time
+// matches the Summary generated for the complete collection and that the
+// zero value is ready for use.
+func TestSummaryBuilder(t *testing.T) {
+	t.Parallel()
+
+	processes := collectTestProcesses(t)
+
+	var builder SummaryBuilder
+	if got := builder.Summary(SummaryOptions{}); got.Total != 0 || !got.IsOKState() {
+		t.Errorf("want empty OK summary from zero value, got %+v", got)
+	}
+
+	for _, p := range processes {
+		builder.Add(p)
+	}
+
+	want := processes.Summary(SummaryOptions{})
+	if got := builder.Summary(SummaryOptions{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("\nwant %+v\ngot  %+v", want, got)
+	}
+}
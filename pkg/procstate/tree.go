@@ -0,0 +1,88 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ProcessNode is a process within a process hierarchy along with the nodes
+// of its child processes.
+type ProcessNode struct {
+	Process  Process
+	Children []*ProcessNode
+}
+
+// Tree returns the process hierarchy of the collection. Processes whose
+// parent is not in the collection (e.g., init and kthreadd) are returned as
+// root nodes. Root and child nodes are sorted by process ID.
+func (ps Processes) Tree() []*ProcessNode {
+	nodes := make(map[int]*ProcessNode, len(ps))
+	for _, p := range ps {
+		nodes[p.Pid] = &ProcessNode{Process: p}
+	}
+
+	var roots []*ProcessNode
+	for _, p := range ps {
+		node := nodes[p.Pid]
+
+		parent, ok := nodes[p.PPid]
+		if !ok || p.PPid == p.Pid {
+			roots = append(roots, node)
+			continue
+		}
+
+		parent.Children = append(parent.Children, node)
+	}
+
+	byPid := func(a, b *ProcessNode) int {
+		return cmp.Compare(a.Process.Pid, b.Process.Pid)
+	}
+
+	slices.SortFunc(roots, byPid)
+	for _, node := range nodes {
+		slices.SortFunc(node.Children, byPid)
+	}
+
+	return roots
+}
+
+// PruneTree returns copies of the given nodes retaining only the branches
+// which contain a process matching the specified predicate function. Nodes
+// for ancestors of matching processes are retained to provide context;
+// other descendants of those ancestors are not.
+func PruneTree(nodes []*ProcessNode, pred Predicate) []*ProcessNode {
+	var pruned []*ProcessNode
+	for _, node := range nodes {
+		children := PruneTree(node.Children, pred)
+		if len(children) > 0 || pred(node.Process) {
+			pruned = append(pruned, &ProcessNode{
+				Process:  node.Process,
+				Children: children,
+			})
+		}
+	}
+
+	return pruned
+}
+
+// CountFunc returns the number of descendants of the node for which the
+// specified predicate function returns true. The node itself is not
+// evaluated.
+func (n *ProcessNode) CountFunc(pred Predicate) int {
+	var ctr int
+	for _, child := range n.Children {
+		if pred(child.Process) {
+			ctr++
+		}
+		ctr += child.CountFunc(pred)
+	}
+
+	return ctr
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Adam Chalkley
+//
+// https://github.com/atc0005/check-process
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package procstate
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// renderTree returns a compact representation of the given nodes (e.g.,
+// "1(10(11))") used to compare process hierarchies.
+func renderTree(nodes []*ProcessNode) string {
+	items := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		item := fmt.Sprintf("%d", node.Process.Pid)
+		if len(node.Children) > 0 {
+			item += "(" + renderTree(node.Children) + ")"
+		}
+		items = append(items, item)
+	}
+
+	return strings.Join(items, " ")
+}
+
+// TestTree asserts that the process hierarchy is built with processes whose
+// parent is not in the collection as root nodes and nodes sorted by process
+// ID.
+func TestTree(t *testing.T) {
+	t.Parallel()
+
+	processes := Processes{
+		{Pid: 20, PPid: 1},
+		{Pid: 1, PPid: 0},
+		{Pid: 2, PPid: 0},
+		{Pid: 12, PPid: 10},
+		{Pid: 10, PPid: 1},
+		{Pid: 11, PPid: 10},
+		{Pid: 30, PPid: 2},
+		{Pid: 40, PPid: 99},
+		{Pid: 50, PPid: 50},
+	}
+
+	want := "1(10(11 12) 20) 2(30) 40 50"
+	if got := renderTree(processes.Tree()); got != want {
+		t.Errorf("\nwant %s\ngot  %s", want, got)
+	}
+}
+
+// TestPruneTree asserts that only branches containing matching processes
+// are retained, including the ancestors of matching processes but not
+// their other descendants, and that the given nodes are not modified.
+func TestPruneTree(t *testing.T) {
+	t.Parallel()
+
+	processes := Processes{
+		{Pid: 1, PPid: 0, State: KernelAnyProcessStateSleeping},
+		{Pid: 2, PPid: 0, State: KernelAnyProcessStateSleeping},
+		{Pid: 3, PPid: 2, State: KernelAnyProcessStateDiskSleep},
+		{Pid: 4, PPid: 2, State: KernelCurrentProcessStateIdle},
+		{Pid: 10, PPid: 1, State: KernelAnyProcessStateSleeping},
+		{Pid: 11, PPid: 10, State: KernelAnyProcessStateSleeping},
+		{Pid: 12, PPid: 11, State: KernelAnyProcessStateZombie},
+		{Pid: 13, PPid: 11, State: KernelAnyProcessStateSleeping},
+		{Pid: 20, PPid: 1, State: KernelAnyProcessStateDiskSleep},
+		{Pid: 21, PPid: 20, State: KernelAnyProcessStateSleeping},
+		{Pid: 30, PPid: 1, State: KernelAnyProcessStateSleeping},
+		{Pid: 31, PPid: 30, State: KernelAnyProcessStateSleeping},
+	}
+
+	roots := processes.Tree()
+	full := renderTree(roots)
+
+	tests := map[string]struct {
+		pred Predicate
+		want string
+	}{
+		"problem processes": {
+			pred: ByState(KnownProblemProcessStates()...),
+			want: "1(10(11(12)) 20) 2(3)",
+		},
+		"root process": {
+			pred: ByPid(1),
+			want: "1",
+		},
+		"leaf process": {
+			pred: ByPid(31),
+			want: "1(30(31))",
+		},
+		"all processes": {
+			pred: And(),
+			want: full,
+		},
+		"no processes": {
+			pred: Or(),
+			want: "",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := renderTree(PruneTree(roots, tt.pred)); got != tt.want {
+				t.Errorf("\nwant %q\ngot  %q", tt.want, got)
+			}
+
+			if got := renderTree(roots); got != full {
+				t.Errorf("original tree modified:\nwant %q\ngot  %q", full, got)
+			}
+		})
+	}
+}
+
+// TestProcessNodeCountFunc asserts that all matching descendants of a node
+// are counted, excluding the node itself.
+func TestProcessNodeCountFunc(t *testing.T) {
+	t.Parallel()
+
+	processes := Processes{
+		{Pid: 1, PPid: 0, State: KernelAnyProcessStateZombie},
+		{Pid: 10, PPid: 1, State: KernelAnyProcessStateDiskSleep},
+		{Pid: 11, PPid: 10, State: KernelAnyProcessStateZombie},
+		{Pid: 12, PPid: 10, State: KernelAnyProcessStateSleeping},
+		{Pid: 20, PPid: 1, State: KernelAnyProcessStateZombie},
+	}
+
+	root := processes.Tree()[0]
+	if got := root.CountFunc(ByState(KnownProblemProcessStates()...)); got != 3 {
+		t.Errorf("want 3 problem descendants, got %d", got)
+	}
+}